@@ -0,0 +1,147 @@
+package myradio
+
+import "fmt"
+
+// AlbumFormat is a single-character code identifying the release format of an album.
+// Codes not in the known table are kept as they are.
+type AlbumFormat string
+
+const (
+	// FormatAlbum is a full-length album.
+	FormatAlbum AlbumFormat = "a"
+	// FormatSingle is a single.
+	FormatSingle AlbumFormat = "s"
+)
+
+// albumFormatNames maps known album format codes to their names.
+var albumFormatNames = map[AlbumFormat]string{
+	FormatAlbum:  "Album",
+	FormatSingle: "Single",
+}
+
+// String gets a human-readable name for the format.
+func (f AlbumFormat) String() string {
+	return codeName(string(f), albumFormatNames[f])
+}
+
+// IsKnown checks whether f is in the table of known format codes.
+func (f AlbumFormat) IsKnown() bool {
+	_, ok := albumFormatNames[f]
+	return ok
+}
+
+// AlbumMedium is a single-character code identifying the physical medium of an album.
+// Codes not in the known table are kept as they are.
+type AlbumMedium string
+
+const (
+	// MediumCD is a compact disc.
+	MediumCD AlbumMedium = "c"
+	// MediumVinyl7 is a 7" vinyl record.
+	MediumVinyl7 AlbumMedium = "7"
+	// MediumVinyl12 is a 12" vinyl record.
+	MediumVinyl12 AlbumMedium = "2"
+	// MediumDigital is a digital-only release, with no physical copy.
+	MediumDigital AlbumMedium = "d"
+)
+
+// albumMediumNames maps known album medium codes to their names.
+var albumMediumNames = map[AlbumMedium]string{
+	MediumCD:      "CD",
+	MediumVinyl7:  `7" Vinyl`,
+	MediumVinyl12: `12" Vinyl`,
+	MediumDigital: "Digital",
+}
+
+// String gets a human-readable name for the medium.
+func (m AlbumMedium) String() string {
+	return codeName(string(m), albumMediumNames[m])
+}
+
+// IsKnown checks whether m is in the table of known medium codes.
+func (m AlbumMedium) IsKnown() bool {
+	_, ok := albumMediumNames[m]
+	return ok
+}
+
+// AlbumStatus is a single-character code identifying the digitisation status of an album.
+// Codes not in the known table are kept as they are.
+type AlbumStatus string
+
+const (
+	// StatusDigitised means the album's tracks have been digitised.
+	StatusDigitised AlbumStatus = "o"
+	// StatusPending means the album is waiting to be digitised.
+	StatusPending AlbumStatus = "p"
+	// StatusNotDigitised means the album has not been, and isn't waiting to be, digitised.
+	StatusNotDigitised AlbumStatus = "n"
+	// StatusDeleted means the album has been removed from the library.
+	StatusDeleted AlbumStatus = "d"
+)
+
+// albumStatusNames maps known album status codes to their names.
+var albumStatusNames = map[AlbumStatus]string{
+	StatusDigitised:    "Digitised",
+	StatusPending:      "Pending Digitisation",
+	StatusNotDigitised: "Not Digitised",
+	StatusDeleted:      "Deleted",
+}
+
+// String gets a human-readable name for the status.
+func (s AlbumStatus) String() string {
+	return codeName(string(s), albumStatusNames[s])
+}
+
+// IsKnown checks whether s is in the table of known status codes.
+func (s AlbumStatus) IsKnown() bool {
+	_, ok := albumStatusNames[s]
+	return ok
+}
+
+// codeName gets name if it isn't empty, and otherwise a placeholder mentioning the unknown code.
+func codeName(code, name string) string {
+	if name != "" {
+		return name
+	}
+	return fmt.Sprintf("Unknown (%q)", code)
+}
+
+// ShelfLocation identifies a shelf in the physical music library.
+type ShelfLocation struct {
+	// Location is the room or area holding the shelf.
+	Location string
+	// ShelfLetter is the letter of the shelf.
+	ShelfLetter string
+}
+
+// GroupAlbumsByFormat groups albums by their format code.
+// This consumes no API requests.
+func GroupAlbumsByFormat(albums []Album) map[AlbumFormat][]Album {
+	groups := make(map[AlbumFormat][]Album)
+	for _, a := range albums {
+		groups[a.Format] = append(groups[a.Format], a)
+	}
+	return groups
+}
+
+// GroupAlbumsByMedium groups albums by their medium code.
+// This consumes no API requests.
+func GroupAlbumsByMedium(albums []Album) map[AlbumMedium][]Album {
+	groups := make(map[AlbumMedium][]Album)
+	for _, a := range albums {
+		groups[a.Medium] = append(groups[a.Medium], a)
+	}
+	return groups
+}
+
+// GroupAlbumsByShelf groups albums by the shelf holding their physical copy.
+// Albums with no physical copy are grouped under the zero ShelfLocation.
+// This consumes no API requests.
+func GroupAlbumsByShelf(albums []Album) map[ShelfLocation][]Album {
+	groups := make(map[ShelfLocation][]Album)
+	for _, a := range albums {
+		loc := ShelfLocation{Location: a.Location, ShelfLetter: a.ShelfLetter}
+		groups[loc] = append(groups[loc], a)
+	}
+	return groups
+}
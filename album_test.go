@@ -0,0 +1,40 @@
+package myradio_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+// TestAlbumCodesRoundTrip tests whether album codes, known or not, survive a JSON round trip.
+func TestAlbumCodesRoundTrip(t *testing.T) {
+	in := `{"recordid":1,"format":"a","media":"x","status":"o"}`
+
+	var album myradio.Album
+	if err := json.Unmarshal([]byte(in), &album); err != nil {
+		t.Fatal(err)
+	}
+
+	if album.Format != myradio.FormatAlbum || album.Format.String() != "Album" {
+		t.Error("expected format Album, got:", album.Format)
+	}
+	if album.Medium.IsKnown() || album.Medium.String() != `Unknown ("x")` {
+		t.Error("expected unknown medium x, got:", album.Medium)
+	}
+	if album.Status != myradio.StatusDigitised {
+		t.Error("expected status Digitised, got:", album.Status)
+	}
+
+	b, err := json.Marshal(album)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back myradio.Album
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back != album {
+		t.Error("expected:", album, "got:", back)
+	}
+}
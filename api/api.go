@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -28,6 +29,9 @@ type Request struct {
 	ReqType HTTPMethod
 	// The body of the request
 	Body bytes.Buffer
+	// Any extra HTTP headers to send with the request.
+	// If a POST request sets its own Content-Type, its Body is sent as-is and its Params go in the query string.
+	Header http.Header
 }
 
 // HTTPMethod guards against incorrect methods being specified through strings
@@ -40,6 +44,8 @@ const (
 	PostReq
 	//PutReq corresponds to PUT
 	PutReq
+	//DeleteReq corresponds to DELETE
+	DeleteReq
 )
 
 // String converts a HTTPMethod object into a usable request method string
@@ -51,6 +57,8 @@ func (m HTTPMethod) String() (string, error) {
 		return "POST", nil
 	case PutReq:
 		return "PUT", nil
+	case DeleteReq:
+		return "DELETE", nil
 	default:
 		return "", errors.New("Invalid HTTP method specified")
 	}
@@ -64,6 +72,7 @@ func NewRequest(endpoint string) *Request {
 		Params:   map[string][]string{},
 		ReqType:  GetReq,
 		Body:     bytes.Buffer{},
+		Header:   http.Header{},
 	}
 }
 
@@ -133,6 +142,13 @@ type Requester interface {
 	Do(r *Request) *Response
 }
 
+// Streamer is the type of Requesters that can also handle requests for raw, non-JSON content, such as audio files.
+type Streamer interface {
+	// Stream fulfils an API request, copying the raw response body to w.
+	// It returns the number of bytes copied.
+	Stream(r *Request, w io.Writer) (int64, error)
+}
+
 // authedRequester answers API requests by making an authed API call.
 type authedRequester struct {
 	apikey  string
@@ -147,12 +163,12 @@ func NewRequester(apikey string, url url.URL) Requester {
 	}
 }
 
-// Do fulfils an API request.
-func (s *authedRequester) Do(r *Request) *Response {
+// newHTTPRequest converts an API request into a HTTP request, adding the API key.
+func (s *authedRequester) newHTTPRequest(r *Request) (*http.Request, error) {
 	//Validate the request method before we waste any time
 	reqMethod, err := r.ReqType.String()
 	if err != nil {
-		return &Response{err: err}
+		return nil, err
 	}
 
 	urlParams := url.Values{
@@ -171,19 +187,34 @@ func (s *authedRequester) Do(r *Request) *Response {
 	theurl.Path += r.Endpoint
 	encodedParams := urlParams.Encode()
 
-	//POST sends form params in the body
-	if r.ReqType == PostReq {
+	// POST sends form params in the body, unless the request brings its own body format
+	formBody := r.ReqType == PostReq && r.Header.Get("Content-Type") == ""
+	if formBody {
 		r.Body.WriteString(encodedParams)
 	} else {
 		theurl.RawQuery = encodedParams
 	}
 	req, err := http.NewRequest(reqMethod, theurl.String(), bytes.NewReader(r.Body.Bytes()))
+	if err != nil {
+		return nil, err
+	}
 
+	for k, vs := range r.Header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
 	// Specify content type for POST requests, as the body format has to be specified
-	if r.ReqType == PostReq {
+	if formBody {
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; param=value")
 	}
 
+	return req, nil
+}
+
+// Do fulfils an API request.
+func (s *authedRequester) Do(r *Request) *Response {
+	req, err := s.newHTTPRequest(r)
 	if err != nil {
 		return &Response{err: err}
 	}
@@ -214,6 +245,50 @@ func (s *authedRequester) Do(r *Request) *Response {
 	return &Response{raw: response.Payload, err: nil}
 }
 
+// Stream fulfils an API request for raw content.
+// Complete (HTTP 200) responses count as success, unless the request asked for a byte range with a Range header.
+// Then only a partial (HTTP 206) response whose Content-Range starts where the request asked counts as success,
+// so that a server ignoring the range can't have its whole file appended to a partial one.
+func (s *authedRequester) Stream(r *Request, w io.Writer) (int64, error) {
+	req, err := s.newHTTPRequest(r)
+	if err != nil {
+		return 0, err
+	}
+	client := &http.Client{}
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	rangeHeader := r.Header.Get("Range")
+	switch {
+	case rangeHeader == "" && res.StatusCode == http.StatusOK:
+	case rangeHeader != "" && res.StatusCode == http.StatusPartialContent:
+		if err := checkContentRange(rangeHeader, res.Header.Get("Content-Range")); err != nil {
+			return 0, fmt.Errorf("%s: %v", r.Endpoint, err)
+		}
+	case rangeHeader != "" && res.StatusCode == http.StatusOK:
+		return 0, fmt.Errorf("%s: asked for %s, but server sent the whole file", r.Endpoint, rangeHeader)
+	default:
+		data, _ := ioutil.ReadAll(res.Body)
+		return 0, fmt.Errorf("%s Not ok: HTTP %d\n%s", r.Endpoint, res.StatusCode, string(data))
+	}
+	return io.Copy(w, res.Body)
+}
+
+// checkContentRange checks that a Content-Range response header starts where a Range request header asked.
+func checkContentRange(rangeHeader, contentRange string) error {
+	var start int64
+	if _, err := fmt.Sscanf(rangeHeader, "bytes=%d-", &start); err != nil {
+		return fmt.Errorf("can't check range %q: %v", rangeHeader, err)
+	}
+	if !strings.HasPrefix(contentRange, fmt.Sprintf("bytes %d-", start)) {
+		return fmt.Errorf("asked for %s, but got Content-Range %q", rangeHeader, contentRange)
+	}
+	return nil
+}
+
 // mockRequester answers API requests by returning some stock response.
 type mockRequester struct {
 	message *json.RawMessage
@@ -228,3 +303,12 @@ func MockRequester(message *json.RawMessage) Requester {
 func (s *mockRequester) Do(r *Request) *Response {
 	return &Response{raw: s.message, err: nil}
 }
+
+// Stream pretends to fulfil an API request for raw content, but actually copies out the mockRequester's stock response.
+func (s *mockRequester) Stream(r *Request, w io.Writer) (int64, error) {
+	if s.message == nil {
+		return 0, nil
+	}
+	n, err := w.Write(*s.message)
+	return int64(n), err
+}
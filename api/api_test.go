@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestNewHTTPRequestPost(t *testing.T) {
+	rq := &authedRequester{apikey: "key", baseurl: url.URL{Scheme: "https", Host: "example.com"}}
+
+	form := NewRequest("/form")
+	form.ReqType = PostReq
+	form.Params["a"] = []string{"b"}
+	req, err := rq.newHTTPRequest(form)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if body, _ := ioutil.ReadAll(req.Body); string(body) != "a=b&api_key=key" {
+		t.Error("expected form params in body, got:", string(body))
+	}
+	if req.URL.RawQuery != "" {
+		t.Error("expected empty query, got:", req.URL.RawQuery)
+	}
+
+	raw := NewRequest("/raw")
+	raw.ReqType = PostReq
+	raw.Params["a"] = []string{"b"}
+	raw.Header.Set("Content-Type", "text/plain")
+	raw.Body.WriteString("hello")
+	req, err = rq.newHTTPRequest(raw)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if body, _ := ioutil.ReadAll(req.Body); string(body) != "hello" {
+		t.Error("expected body to be sent as-is, got:", string(body))
+	}
+	if req.URL.RawQuery != "a=b&api_key=key" {
+		t.Error("expected params in query, got:", req.URL.RawQuery)
+	}
+	if ct := req.Header.Get("Content-Type"); ct != "text/plain" {
+		t.Error("expected Content-Type to be kept, got:", ct)
+	}
+}
+
+func TestStreamRange(t *testing.T) {
+	const file = "0123456789"
+	var honourRange, badContentRange bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var start int
+		if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &start); err != nil || !honourRange {
+			io.WriteString(w, file)
+			return
+		}
+		if badContentRange {
+			start++
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(file)-1, len(file)))
+		w.WriteHeader(http.StatusPartialContent)
+		io.WriteString(w, file[start:])
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := NewRequester("key", *u).(Streamer)
+
+	tests := []struct {
+		offset           int
+		honour, badRange bool
+		expected         string
+		expectErr        bool
+	}{
+		{0, false, false, file, false},
+		{4, true, false, file[4:], false},
+		{4, false, false, "", true},
+		{4, true, true, "", true},
+	}
+	for _, test := range tests {
+		honourRange, badContentRange = test.honour, test.badRange
+		rq := NewRequest("/file")
+		if test.offset > 0 {
+			rq.Header.Set("Range", fmt.Sprintf("bytes=%d-", test.offset))
+		}
+
+		var buf bytes.Buffer
+		_, err := st.Stream(rq, &buf)
+		if (err != nil) != test.expectErr {
+			t.Errorf("offset %d, honour %v, bad range %v: expected error %v, got: %v", test.offset, test.honour, test.badRange, test.expectErr, err)
+		}
+		if buf.String() != test.expected {
+			t.Errorf("offset %d, honour %v, bad range %v: expected %q, got %q", test.offset, test.honour, test.badRange, test.expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package myradio
+
+import (
+	"context"
+	"time"
+)
+
+// currentAndNextSlack is how long after a show's end time we wait before polling, to give MyRadio time to move on.
+const currentAndNextSlack = 2 * time.Second
+
+// CurrentAndNextEvent reports a change in the current or next show.
+type CurrentAndNextEvent struct {
+	// Previous is the current and next show before the change.
+	// It is nil for the event sent on the first poll.
+	Previous *CurrentAndNext
+	// Now is the current and next show after the change.
+	Now *CurrentAndNext
+	// CurrentChanged is true if the current show changed.
+	CurrentChanged bool
+	// NextChanged is true if the next show changed.
+	NextChanged bool
+	// Time is the time at which the change was seen.
+	Time time.Time
+}
+
+// CurrentAndNextWatcher polls the current and next shows, reporting each change to them.
+type CurrentAndNextWatcher struct {
+	session  *Session
+	interval time.Duration
+}
+
+// NewCurrentAndNextWatcher constructs a CurrentAndNextWatcher that polls at least every interval.
+// An interval of zero or less means DefaultPollInterval.
+func (s *Session) NewCurrentAndNextWatcher(interval time.Duration) *CurrentAndNextWatcher {
+	return &CurrentAndNextWatcher{session: s, interval: pollInterval(interval)}
+}
+
+// Run polls the current and next shows until ctx is done, sending an event on out whenever either changes.
+// The first poll always sends an event, so that consumers can start from a known state.
+// When the current show is due to end before the next poll, Run polls just after it ends instead.
+// Run returns ctx's error once ctx is done, or the first error it gets from the API.
+// Each poll consumes one API request.
+func (w *CurrentAndNextWatcher) Run(ctx context.Context, out chan<- CurrentAndNextEvent) error {
+	var last *CurrentAndNext
+	return poll(ctx, func() (time.Duration, error) {
+		can, err := w.session.GetCurrentAndNext()
+		if err != nil {
+			return 0, err
+		}
+
+		ev := CurrentAndNextEvent{
+			Previous:       last,
+			Now:            can,
+			CurrentChanged: last == nil || !sameShow(last.Current, can.Current),
+			NextChanged:    last == nil || !sameShow(last.Next, can.Next),
+			Time:           time.Now(),
+		}
+		if ev.CurrentChanged || ev.NextChanged {
+			select {
+			case out <- ev:
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+		last = can
+
+		return w.nextPoll(can), nil
+	})
+}
+
+// nextPoll works out how long to wait before polling again, given the latest current and next shows.
+func (w *CurrentAndNextWatcher) nextPoll(can *CurrentAndNext) time.Duration {
+	if !can.Current.Ends() {
+		return w.interval
+	}
+	// If the show has already ended, MyRadio hasn't caught up yet; fall back to the usual interval.
+	if untilEnd := time.Until(can.Current.EndTime.Time); 0 < untilEnd && untilEnd+currentAndNextSlack < w.interval {
+		return untilEnd + currentAndNextSlack
+	}
+	return w.interval
+}
+
+// sameShow checks whether a and b describe the same show in the same slot.
+func sameShow(a, b Show) bool {
+	return a.Id == b.Id &&
+		a.Title == b.Title &&
+		a.StartTime.Equal(b.StartTime.Time) &&
+		a.EndTime.Equal(b.EndTime.Time)
+}
@@ -0,0 +1,67 @@
+package myradio_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+const currentAndNextJSON = `{
+	"current": {"title": "Jukebox", "start_time": 1479081600, "end_time": 1479085200},
+	"next": {"title": "The Breakfast Show", "start_time": 1479085200, "end_time": "The End of Time"}
+}`
+
+// TestCurrentAndNextWatcher tests whether a CurrentAndNextWatcher reports its first poll and then stops cleanly.
+// It does not test the API endpoint.
+func TestCurrentAndNextWatcher(t *testing.T) {
+	session, err := myradio.MockSession([]byte(currentAndNextJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan myradio.CurrentAndNextEvent)
+	done := make(chan error)
+	go func() {
+		done <- session.NewCurrentAndNextWatcher(time.Millisecond).Run(ctx, events)
+	}()
+
+	ev := <-events
+	if ev.Previous != nil || !ev.CurrentChanged || !ev.NextChanged {
+		t.Error("first event should report a change from nothing, got:", ev)
+	}
+	if ev.Now.Current.Title != "Jukebox" || ev.Now.Next.Title != "The Breakfast Show" {
+		t.Error("first event has wrong shows:", ev.Now)
+	}
+
+	// The mock never changes, so there should be no further events.
+	select {
+	case ev := <-events:
+		t.Error("unexpected event:", ev)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Error("expected:", context.Canceled, "got:", err)
+	}
+}
+
+// TestGetCurrentAndNextNull tests whether GetCurrentAndNext copes with MyRadio sending back nothing.
+// It does not test the API endpoint.
+func TestGetCurrentAndNextNull(t *testing.T) {
+	session, err := myradio.MockSession([]byte("null"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	can, err := session.GetCurrentAndNext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if can == nil || !can.Current.EndTime.IsZero() || !can.Next.EndTime.IsZero() {
+		t.Error("expected empty current and next shows, got:", can)
+	}
+}
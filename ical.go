@@ -0,0 +1,139 @@
+package myradio
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// icalProdID identifies this library as the producer of iCalendar files.
+	icalProdID = "-//University Radio York//myradio-go//EN"
+	// icalUIDDomain is the domain used to make timeslot UIDs globally unique.
+	icalUIDDomain = "ury.org.uk"
+	// icalTimeFormat is the RFC 5545 format for UTC date-times.
+	icalTimeFormat = "20060102T150405Z"
+	// icalLineLimit is the maximum length, in octets, of an iCalendar content line.
+	icalLineLimit = 75
+)
+
+// WriteICalendar writes timeslots to w as an RFC 5545 iCalendar, with one event per timeslot.
+// Each event's UID is derived from its TimeslotID, so re-exporting the same timeslot updates, rather than duplicates,
+// any existing calendar entry.
+// This consumes no API requests.
+func WriteICalendar(w io.Writer, timeslots []Timeslot) error {
+	return writeICalendar(w, timeslots, time.Now())
+}
+
+// WriteWeekScheduleICalendar writes a schedule from GetWeekSchedule to w as an RFC 5545 iCalendar.
+// Events appear in weekday order, Monday first.
+// This consumes no API requests.
+func WriteWeekScheduleICalendar(w io.Writer, schedule map[int][]Timeslot) error {
+	return WriteICalendar(w, FlattenWeekSchedule(schedule))
+}
+
+// WriteShowICalendar writes every timeslot of every season of the show with the given ID to w as an RFC 5545
+// iCalendar.
+// This consumes one API request, plus one for each of the show's seasons.
+func (s *Session) WriteShowICalendar(w io.Writer, showID int) error {
+	seasons, err := s.GetSeasons(showID)
+	if err != nil {
+		return err
+	}
+
+	var timeslots []Timeslot
+	for _, season := range seasons {
+		ts, err := s.GetTimeslotsForSeason(season.SeasonID)
+		if err != nil {
+			return err
+		}
+		timeslots = append(timeslots, ts...)
+	}
+	return WriteICalendar(w, timeslots)
+}
+
+// writeICalendar writes timeslots to w as an iCalendar, stamping each event with stamp.
+func writeICalendar(w io.Writer, timeslots []Timeslot, stamp time.Time) error {
+	iw := icalWriter{w: w}
+	iw.line("BEGIN", "VCALENDAR")
+	iw.line("VERSION", "2.0")
+	iw.line("PRODID", icalProdID)
+	iw.line("CALSCALE", "GREGORIAN")
+	for _, t := range timeslots {
+		iw.event(t, stamp)
+	}
+	iw.line("END", "VCALENDAR")
+	return iw.err
+}
+
+// icalWriter writes iCalendar content lines, remembering the first error it sees.
+type icalWriter struct {
+	w   io.Writer
+	err error
+}
+
+// event writes t as a VEVENT.
+func (iw *icalWriter) event(t Timeslot, stamp time.Time) {
+	iw.line("BEGIN", "VEVENT")
+	iw.line("UID", fmt.Sprintf("timeslot-%d@%s", t.TimeslotID, icalUIDDomain))
+	iw.line("DTSTAMP", stamp.UTC().Format(icalTimeFormat))
+	iw.line("DTSTART", t.StartTime.UTC().Format(icalTimeFormat))
+	iw.line("DTEND", t.StartTime.Add(t.Duration).UTC().Format(icalTimeFormat))
+	iw.line("SUMMARY", icalEscape(t.Title))
+
+	desc := t.Description
+	if t.CreditsString != "" {
+		if desc != "" {
+			desc += "\n\n"
+		}
+		desc += "With " + t.CreditsString
+	}
+	if desc != "" {
+		iw.line("DESCRIPTION", icalEscape(desc))
+	}
+	if t.MicroSiteLink.URL != "" {
+		iw.line("URL", t.MicroSiteLink.URL)
+	}
+	iw.line("END", "VEVENT")
+}
+
+// line writes a single content line, folding it if it exceeds the RFC 5545 line limit.
+func (iw *icalWriter) line(name, value string) {
+	if iw.err != nil {
+		return
+	}
+	_, iw.err = io.WriteString(iw.w, icalFold(name+":"+value)+"\r\n")
+}
+
+// icalEscape escapes the characters RFC 5545 reserves in TEXT values.
+func icalEscape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// icalFold splits line into chunks of at most icalLineLimit octets, joined by the RFC 5545 continuation sequence.
+// It never splits a UTF-8 sequence.
+func icalFold(line string) string {
+	var sb strings.Builder
+	limit := icalLineLimit
+	for len(line) > limit {
+		cut := limit
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines lose an octet to the leading space.
+		limit = icalLineLimit - 1
+	}
+	sb.WriteString(line)
+	return sb.String()
+}
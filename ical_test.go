@@ -0,0 +1,65 @@
+package myradio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteICalendar(t *testing.T) {
+	ts := Timeslot{
+		Season: Season{
+			ShowMeta: ShowMeta{
+				Title:         "Jam, Toast; and Tea",
+				CreditsString: "Tommy Tutone",
+				MicroSiteLink: Link{URL: "https://ury.org.uk/schedule/shows/1"},
+			},
+		},
+		TimeslotID: 42,
+		StartTime:  time.Date(2009, time.April, 13, 11, 0, 0, 0, time.UTC),
+		Duration:   time.Hour,
+	}
+	stamp := time.Date(2009, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + icalProdID,
+		"CALSCALE:GREGORIAN",
+		"BEGIN:VEVENT",
+		"UID:timeslot-42@ury.org.uk",
+		"DTSTAMP:20090401T000000Z",
+		"DTSTART:20090413T110000Z",
+		"DTEND:20090413T120000Z",
+		`SUMMARY:Jam\, Toast\; and Tea`,
+		"DESCRIPTION:With Tommy Tutone",
+		"URL:https://ury.org.uk/schedule/shows/1",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	var buf bytes.Buffer
+	if err := writeICalendar(&buf, []Timeslot{ts}, stamp); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if buf.String() != expected {
+		t.Errorf("expected:\n%q\n\ngot:\n%q", expected, buf.String())
+	}
+}
+
+func TestICalFold(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("é", 100)
+	for i, l := range strings.Split(icalFold(line), "\r\n") {
+		if icalLineLimit < len(l) {
+			t.Errorf("line %d is %d octets long", i, len(l))
+		}
+		if 0 < i && l[0] != ' ' {
+			t.Errorf("continuation line %d doesn't start with a space", i)
+		}
+	}
+	if unfolded := strings.Replace(icalFold(line), "\r\n ", "", -1); unfolded != line {
+		t.Error("expected:", line, "got:", unfolded)
+	}
+}
@@ -0,0 +1,147 @@
+package myradio
+
+import (
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+)
+
+// NewMember holds the details needed to sign up a new member, or reactivate a lapsed one.
+type NewMember struct {
+	// Fname and Sname are the member's first name and surname.
+	Fname, Sname string
+	// Email is the member's personal email address, as a bare address such as "jo@example.org".
+	// It may be left empty if Eduroam is set.
+	Email string
+	// Eduroam is the member's university username, without the domain.
+	// It may be left empty if Email is set.
+	Eduroam string
+	// CollegeID is the ID of the member's college, as given by GetColleges.
+	CollegeID int
+	// Phone is the member's phone number, if they gave one.
+	Phone string
+	// ReceiveEmail is true if the member wants email from the station's mailing lists.
+	ReceiveEmail bool
+}
+
+// Validate checks that the member's details are complete and well-formed, and that their college is one of
+// colleges.
+// It returns a *FieldError for the first bad field it finds.
+// This consumes no API requests.
+func (m *NewMember) Validate(colleges []College) error {
+	if strings.TrimSpace(m.Fname) == "" {
+		return &FieldError{Field: "Fname", Problem: "must not be empty"}
+	}
+	if strings.TrimSpace(m.Sname) == "" {
+		return &FieldError{Field: "Sname", Problem: "must not be empty"}
+	}
+	if m.Email == "" && m.Eduroam == "" {
+		return &FieldError{Field: "Email", Problem: "must be given if Eduroam isn't"}
+	}
+	if m.Email != "" {
+		if addr, err := mail.ParseAddress(m.Email); err != nil || addr.Address != m.Email {
+			return &FieldError{Field: "Email", Problem: fmt.Sprintf("%q is not an email address", m.Email)}
+		}
+	}
+	if m.Eduroam != "" && !isUsername(m.Eduroam) {
+		return &FieldError{Field: "Eduroam", Problem: fmt.Sprintf("%q is not a university username", m.Eduroam)}
+	}
+	if !hasCollege(colleges, m.CollegeID) {
+		return &FieldError{Field: "CollegeID", Problem: fmt.Sprintf("%d is not a known college", m.CollegeID)}
+	}
+	if m.Phone != "" && !isPhoneNumber(m.Phone) {
+		return &FieldError{Field: "Phone", Problem: fmt.Sprintf("%q is not a phone number", m.Phone)}
+	}
+	return nil
+}
+
+// formParams gets the CreateOrActivateUser form parameters for the member.
+func (m *NewMember) formParams() map[string][]string {
+	return map[string][]string{
+		"fname":         {strings.TrimSpace(m.Fname)},
+		"sname":         {strings.TrimSpace(m.Sname)},
+		"email":         {m.Email},
+		"eduroam":       {m.Eduroam},
+		"collegeid":     {strconv.Itoa(m.CollegeID)},
+		"phone":         {m.Phone},
+		"receive_email": {strconv.FormatBool(m.ReceiveEmail)},
+	}
+}
+
+// isUsername checks whether s looks like a university username: lower-case letters and digits only.
+func isUsername(s string) bool {
+	for _, c := range s {
+		if !('a' <= c && c <= 'z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// isPhoneNumber checks whether s is made of digits, spaces and an optional leading '+', with at least one digit.
+func isPhoneNumber(s string) bool {
+	digits := 0
+	for i, c := range s {
+		switch {
+		case '0' <= c && c <= '9':
+			digits++
+		case c == ' ':
+		case c == '+' && i == 0:
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+// hasCollege checks whether a college with the given ID is in colleges.
+func hasCollege(colleges []College, id int) bool {
+	for _, c := range colleges {
+		if c.CollegeId == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateOrActivateMember signs up m as a new member, or reactivates their account if they already have one.
+// It reports whether a new account was created.
+// MyRadio doesn't say which it did, so created is a best-effort guess: it is false if a search by m's university
+// username or email address, made before signing up, found an account with either of them.
+// The search relies on the personal_data mixin, so the guess is only as good as the API key's access to it.
+// A failed search doesn't stop the signup; it counts as finding nothing.
+// If m fails validation, the error is the one from NewMember.Validate.
+// This consumes three API requests, plus one more if m has both a university username and an email address and no
+// account was found by the username.
+func (s *Session) CreateOrActivateMember(m NewMember) (user *User, created bool, err error) {
+	colleges, err := s.GetColleges()
+	if err != nil {
+		return
+	}
+	if err = m.Validate(colleges); err != nil {
+		return
+	}
+
+	created = true
+	if m.Eduroam != "" {
+		// The search errors are dropped on purpose: they only cost us the guess, and shouldn't block the signup.
+		existing, _ := s.SearchUsersByUsername(m.Eduroam, 0, 0)
+		for _, u := range existing {
+			if strings.EqualFold(u.Eduroam, m.Eduroam) {
+				created = false
+			}
+		}
+	}
+	if created && m.Email != "" {
+		existing, _ := s.SearchUsersByEmail(m.Email, 0, 0)
+		for _, u := range existing {
+			if strings.EqualFold(u.PersonalEmail, m.Email) || strings.EqualFold(u.Email, m.Email) {
+				created = false
+			}
+		}
+	}
+
+	user, err = s.CreateOrActivateUser(m.formParams())
+	return
+}
@@ -0,0 +1,107 @@
+package myradio
+
+import (
+	"testing"
+)
+
+// TestNewMemberValidate tests whether NewMember.Validate picks out the offending field.
+func TestNewMemberValidate(t *testing.T) {
+	colleges := []College{{CollegeId: 1, CollegeName: "Derwent"}}
+	valid := NewMember{Fname: "Jo", Sname: "Bloggs", Eduroam: "jb123", CollegeID: 1, Phone: "+44 1904 000000"}
+
+	cases := []struct {
+		edit  func(m *NewMember)
+		field string
+	}{
+		{func(m *NewMember) {}, ""},
+		{func(m *NewMember) { m.Sname = " " }, "Sname"},
+		{func(m *NewMember) { m.Eduroam = "" }, "Email"},
+		{func(m *NewMember) { m.Email = "not an address" }, "Email"},
+		{func(m *NewMember) { m.Email = "Jo Bloggs <jo@example.org>" }, "Email"},
+		{func(m *NewMember) { m.Email = "jo@example.org" }, ""},
+		{func(m *NewMember) { m.Eduroam = "jb123@york.ac.uk" }, "Eduroam"},
+		{func(m *NewMember) { m.CollegeID = 2 }, "CollegeID"},
+		{func(m *NewMember) { m.Phone = "01904-000000" }, "Phone"},
+	}
+	for i, c := range cases {
+		m := valid
+		c.edit(&m)
+		err := m.Validate(colleges)
+		if c.field == "" {
+			if err != nil {
+				t.Errorf("case %d: unexpected error: %v", i, err)
+			}
+			continue
+		}
+		if fe, ok := err.(*FieldError); !ok || fe.Field != c.field {
+			t.Errorf("case %d: expected error in %s, got: %v", i, c.field, err)
+		}
+	}
+}
+
+// TestCreateOrActivateMember tests whether CreateOrActivateMember reports a lapsed member found by email, but not by
+// university username, as reactivated.
+// It does not test the API endpoint.
+func TestCreateOrActivateMember(t *testing.T) {
+	const user = `{"memberid": 10, "fname": "Jo", "sname": "Bloggs", "email": "jo@example.org", "eduroam": ""}`
+	cases := []struct {
+		byEmail string
+		created bool
+	}{
+		{`[` + user + `]`, false},
+		{`[]`, true},
+	}
+	for _, c := range cases {
+		session, requester := scriptedSession(map[string][]string{
+			"/user/colleges":         {`[{"value": "1", "text": "Derwent"}]`},
+			"/user/findbyeduroam":    {`[]`},
+			"/user/findbyemail":      {c.byEmail},
+			"/user/createoractivate": {user},
+		})
+
+		m := NewMember{Fname: "Jo", Sname: "Bloggs", Email: "jo@example.org", Eduroam: "jb123", CollegeID: 1}
+		u, created, err := session.CreateOrActivateMember(m)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if created != c.created {
+			t.Errorf("expected created=%v, got %v", c.created, created)
+		}
+		if u == nil || u.MemberID != 10 {
+			t.Error("expected member 10, got:", u)
+		}
+		if n := len(requester.sent()); n != 4 {
+			t.Errorf("expected 4 requests, got %d", n)
+		}
+	}
+}
+
+// TestCreateOrActivateMemberSearchFails tests whether CreateOrActivateMember still signs up a member when it can't
+// search for their existing account.
+// It does not test the API endpoint.
+func TestCreateOrActivateMemberSearchFails(t *testing.T) {
+	// A string where MyRadio should send a list of users makes the searches fail to decode.
+	session, requester := scriptedSession(map[string][]string{
+		"/user/colleges":         {`[{"value": "1", "text": "Derwent"}]`},
+		"/user/findbyeduroam":    {`"oops"`},
+		"/user/findbyemail":      {`"oops"`},
+		"/user/createoractivate": {`{"memberid": 10, "fname": "Jo", "sname": "Bloggs"}`},
+	})
+
+	m := NewMember{Fname: "Jo", Sname: "Bloggs", Email: "jo@example.org", Eduroam: "jb123", CollegeID: 1}
+	u, created, err := session.CreateOrActivateMember(m)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !created {
+		t.Error("expected created=true when nothing could be found")
+	}
+	if u == nil || u.MemberID != 10 {
+		t.Error("expected member 10, got:", u)
+	}
+
+	sent := requester.sent()
+	if len(sent) != 4 || sent[3].Endpoint != "/user/createoractivate" {
+		t.Errorf("expected 4 requests ending in /user/createoractivate, got %d", len(sent))
+	}
+}
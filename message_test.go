@@ -0,0 +1,115 @@
+package myradio
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestGetTimeslotMessages tests whether GetTimeslotMessages drops messages the caller has already seen.
+// It does not test the API endpoint.
+func TestGetTimeslotMessages(t *testing.T) {
+	session, _ := scriptedSession(map[string][]string{
+		"/timeslot/5/messages": {`[
+			{"id": 2, "sender": "Jo", "time": 1479081600, "title": "Hi", "body": "Hello", "read": true},
+			{"id": 3, "sender": "Sam", "time": 1479081660, "title": "Request", "body": "Play some Tutone"}
+		]`},
+	})
+
+	messages, err := session.GetTimeslotMessages(5, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Message{
+		{ID: 3, Sender: "Sam", Time: Time{time.Unix(1479081660, 0)}, Title: "Request", Body: "Play some Tutone"},
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("expected:\n%v\n\ngot:\n%v", expected, messages)
+	}
+}
+
+// TestMessageWatcher tests whether a MessageWatcher skips messages it has already seen and asks only for newer ones.
+// It does not test the API endpoint.
+func TestMessageWatcher(t *testing.T) {
+	session, requester := scriptedSession(map[string][]string{
+		"/timeslot/5/messages": {
+			`[{"id": 1}, {"id": 2}]`,
+			`[{"id": 2}, {"id": 3}]`,
+			`[]`,
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	messages := make(chan Message)
+	done := make(chan error)
+	go func() {
+		done <- session.NewMessageWatcher(5, 0, time.Millisecond).Run(ctx, messages)
+	}()
+
+	for _, id := range []uint64{1, 2, 3} {
+		if m := <-messages; m.ID != id {
+			t.Errorf("expected message %d, got %d", id, m.ID)
+		}
+	}
+	select {
+	case m := <-messages:
+		t.Error("unexpected message:", m)
+	case <-time.After(10 * time.Millisecond):
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Error("expected:", context.Canceled, "got:", err)
+	}
+
+	var offsets []string
+	for _, rq := range requester.sent()[:3] {
+		offsets = append(offsets, rq.Params["offset"][0])
+	}
+	if expected := []string{"0", "2", "3"}; !reflect.DeepEqual(offsets, expected) {
+		t.Errorf("expected offsets %v, got %v", expected, offsets)
+	}
+}
+
+// TestMessageWatcherOutOfOrder tests whether a MessageWatcher reports messages that come back out of order by ID,
+// and never asks for messages older than the newest it has seen.
+// It does not test the API endpoint.
+func TestMessageWatcherOutOfOrder(t *testing.T) {
+	session, requester := scriptedSession(map[string][]string{
+		"/timeslot/5/messages": {
+			`[{"id": 3}, {"id": 1}, {"id": 2}]`,
+			`[{"id": 5}, {"id": 4}]`,
+			`[]`,
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	messages := make(chan Message)
+	done := make(chan error)
+	go func() {
+		done <- session.NewMessageWatcher(5, 0, time.Millisecond).Run(ctx, messages)
+	}()
+
+	for _, id := range []uint64{1, 2, 3, 4, 5} {
+		if m := <-messages; m.ID != id {
+			t.Errorf("expected message %d, got %d", id, m.ID)
+		}
+	}
+	select {
+	case m := <-messages:
+		t.Error("unexpected message:", m)
+	case <-time.After(10 * time.Millisecond):
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Error("expected:", context.Canceled, "got:", err)
+	}
+
+	var offsets []string
+	for _, rq := range requester.sent()[:3] {
+		offsets = append(offsets, rq.Params["offset"][0])
+	}
+	if expected := []string{"0", "3", "5"}; !reflect.DeepEqual(offsets, expected) {
+		t.Errorf("expected offsets %v, got %v", expected, offsets)
+	}
+}
@@ -0,0 +1,46 @@
+package myradio
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/UniversityRadioYork/myradio-go/api"
+)
+
+// scriptedRequester answers each endpoint with a scripted series of JSON payloads, recording every request.
+// The last payload for an endpoint repeats forever; endpoints with no payloads answer null.
+type scriptedRequester struct {
+	mu        sync.Mutex
+	responses map[string][]string
+	requests  []*api.Request
+}
+
+// scriptedSession creates a Session whose requests are answered by a scriptedRequester with the given responses.
+func scriptedSession(responses map[string][]string) (*Session, *scriptedRequester) {
+	r := &scriptedRequester{responses: responses}
+	return &Session{requester: r}, r
+}
+
+// Do answers r with the next payload scripted for its endpoint.
+func (s *scriptedRequester) Do(r *api.Request) *api.Response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r)
+	payload := "null"
+	if queue := s.responses[r.Endpoint]; 0 < len(queue) {
+		payload = queue[0]
+		if 1 < len(queue) {
+			s.responses[r.Endpoint] = queue[1:]
+		}
+	}
+	rm := json.RawMessage(payload)
+	return api.MockRequester(&rm).Do(r)
+}
+
+// sent gets a copy of the requests made so far.
+func (s *scriptedRequester) sent() []*api.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*api.Request(nil), s.requests...)
+}
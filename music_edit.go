@@ -0,0 +1,175 @@
+package myradio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// FieldError reports a field of an update that failed validation.
+type FieldError struct {
+	// Field is the name of the offending struct field.
+	Field string
+	// Problem describes what is wrong with the field.
+	Problem string
+}
+
+// Error gets a description of the validation failure.
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Problem
+}
+
+// TrackUpdate describes changes to a track in the URY track database.
+// Only non-nil fields are changed.
+type TrackUpdate struct {
+	// Title is the new title of the track.
+	Title *string
+	// Artist is the new primary credited artist of the track.
+	Artist *string
+	// IsClean is the new clean flag of the track.
+	IsClean *bool
+	// Intro is the new length of the track's intro, in seconds.
+	Intro *uint64
+}
+
+// Validate checks that the update changes something, and that every field it changes is well-formed.
+// It returns a *FieldError for the first bad field it finds.
+// This consumes no API requests.
+func (u *TrackUpdate) Validate() error {
+	if u.Title == nil && u.Artist == nil && u.IsClean == nil && u.Intro == nil {
+		return errors.New("track update changes nothing")
+	}
+	if u.Title != nil && strings.TrimSpace(*u.Title) == "" {
+		return &FieldError{Field: "Title", Problem: "must not be empty"}
+	}
+	if u.Artist != nil && strings.TrimSpace(*u.Artist) == "" {
+		return &FieldError{Field: "Artist", Problem: "must not be empty"}
+	}
+	return nil
+}
+
+// values gets the form parameters for the update, named after the Track JSON fields.
+func (u *TrackUpdate) values() url.Values {
+	v := url.Values{}
+	if u.Title != nil {
+		v.Set("title", *u.Title)
+	}
+	if u.Artist != nil {
+		v.Set("artist", *u.Artist)
+	}
+	if u.IsClean != nil {
+		v.Set("clean", strconv.FormatBool(*u.IsClean))
+	}
+	if u.Intro != nil {
+		v.Set("intro", strconv.FormatUint(*u.Intro, 10))
+	}
+	return v
+}
+
+// UpdateTrack applies update to the track with the given ID, returning the updated track.
+// If the update fails validation, the error is the one from TrackUpdate.Validate.
+// This consumes one API request.
+func (s *Session) UpdateTrack(trackid uint64, update TrackUpdate) (track *Track, err error) {
+	if err = update.Validate(); err != nil {
+		return nil, err
+	}
+
+	body := bytes.NewBufferString(update.values().Encode())
+	err = s.putf("/track/%d", *body, trackid).Into(&track)
+	return
+}
+
+// AlbumUpdate describes changes to an album in the URY track database.
+// Only non-nil fields are changed.
+type AlbumUpdate struct {
+	// Title is the new title of the album.
+	Title *string
+	// Artist is the new primary credited artist of the album.
+	Artist *string
+	// RecordLabel is the new record label of the album.
+	RecordLabel *string
+	// Format is the new format code of the album.
+	Format *AlbumFormat
+	// Medium is the new medium code of the album.
+	Medium *AlbumMedium
+	// Location is the new location of the physical copy of the album.
+	Location *string
+	// ShelfLetter is the new shelf on which the physical copy resides.
+	ShelfLetter *string
+	// ShelfNumber is the new position on the shelf of the physical copy.
+	ShelfNumber *uint64
+}
+
+// Validate checks that the update changes something, and that every field it changes is well-formed.
+// It returns a *FieldError for the first bad field it finds.
+// This consumes no API requests.
+func (u *AlbumUpdate) Validate() error {
+	if len(u.values()) == 0 {
+		return errors.New("album update changes nothing")
+	}
+	if u.Title != nil && strings.TrimSpace(*u.Title) == "" {
+		return &FieldError{Field: "Title", Problem: "must not be empty"}
+	}
+	if u.Artist != nil && strings.TrimSpace(*u.Artist) == "" {
+		return &FieldError{Field: "Artist", Problem: "must not be empty"}
+	}
+	if u.Format != nil && !u.Format.IsKnown() {
+		return &FieldError{Field: "Format", Problem: fmt.Sprintf("%q is not a known format code", string(*u.Format))}
+	}
+	if u.Medium != nil && !u.Medium.IsKnown() {
+		return &FieldError{Field: "Medium", Problem: fmt.Sprintf("%q is not a known medium code", string(*u.Medium))}
+	}
+	if u.ShelfLetter != nil && !isShelfLetter(*u.ShelfLetter) {
+		return &FieldError{Field: "ShelfLetter", Problem: fmt.Sprintf("%q is not a single letter", *u.ShelfLetter)}
+	}
+	return nil
+}
+
+// isShelfLetter checks whether s is empty or a single ASCII letter.
+func isShelfLetter(s string) bool {
+	if s == "" {
+		return true
+	}
+	return len(s) == 1 && ('A' <= s[0] && s[0] <= 'Z' || 'a' <= s[0] && s[0] <= 'z')
+}
+
+// values gets the form parameters for the update, named after the Album JSON fields.
+func (u *AlbumUpdate) values() url.Values {
+	v := url.Values{}
+	set := func(key string, value *string) {
+		if value != nil {
+			v.Set(key, *value)
+		}
+	}
+	set("title", u.Title)
+	set("artist", u.Artist)
+	set("record_label", u.RecordLabel)
+	set("location", u.Location)
+	set("shelf_letter", u.ShelfLetter)
+	if u.Format != nil {
+		v.Set("format", string(*u.Format))
+	}
+	if u.Medium != nil {
+		v.Set("media", string(*u.Medium))
+	}
+	if u.ShelfNumber != nil {
+		v.Set("shelf_number", strconv.FormatUint(*u.ShelfNumber, 10))
+	}
+	return v
+}
+
+// UpdateAlbum applies update to the album with the given ID, returning the updated album.
+// If the update fails validation, the error is the one from AlbumUpdate.Validate.
+// This consumes one API request.
+func (s *Session) UpdateAlbum(albumid uint64, update AlbumUpdate) (album *Album, err error) {
+	if err = update.Validate(); err != nil {
+		return nil, err
+	}
+
+	body := bytes.NewBufferString(update.values().Encode())
+	err = s.putf("/album/%d", *body, albumid).Into(&album)
+	return
+}
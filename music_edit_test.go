@@ -0,0 +1,55 @@
+package myradio_test
+
+import (
+	"reflect"
+	"testing"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+// TestUpdateTrack tests whether UpdateTrack returns the updated track.
+func TestUpdateTrack(t *testing.T) {
+	session, _ := myradio.MockSession([]byte(`{"trackid": 7, "title": "Toast", "artist": "Tommy", "clean": true}`))
+
+	title := "Toast"
+	track, err := session.UpdateTrack(7, myradio.TrackUpdate{Title: &title})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &myradio.Track{ID: 7, Title: "Toast", Artist: "Tommy", IsClean: true}
+	if !reflect.DeepEqual(track, expected) {
+		t.Errorf("expected:\n%v\n\ngot:\n%v", expected, track)
+	}
+}
+
+// TestAlbumUpdateValidate tests whether AlbumUpdate.Validate picks out the offending field.
+func TestAlbumUpdateValidate(t *testing.T) {
+	empty, label, shelf := "", "Lino", "AB"
+	format, medium := myradio.AlbumFormat("x"), myradio.MediumCD
+
+	cases := []struct {
+		update myradio.AlbumUpdate
+		field  string
+	}{
+		{myradio.AlbumUpdate{RecordLabel: &label, Medium: &medium}, ""},
+		{myradio.AlbumUpdate{Title: &empty}, "Title"},
+		{myradio.AlbumUpdate{Format: &format}, "Format"},
+		{myradio.AlbumUpdate{ShelfLetter: &shelf}, "ShelfLetter"},
+	}
+	for _, c := range cases {
+		err := c.update.Validate()
+		if c.field == "" {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			continue
+		}
+		if fe, ok := err.(*myradio.FieldError); !ok || fe.Field != c.field {
+			t.Errorf("expected error in %s, got: %v", c.field, err)
+		}
+	}
+
+	if err := (&myradio.AlbumUpdate{}).Validate(); err == nil {
+		t.Error("expected an error for an empty update")
+	}
+}
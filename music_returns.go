@@ -0,0 +1,130 @@
+package myradio
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// musicReturnsHeader is the header row of a music returns file.
+var musicReturnsHeader = []string{"Title", "Artist", "Album", "Label", "Play Count", "Duration", "Notes"}
+
+// MusicReturn is one line of a PRS/PPL music return: a track, and how many times it was played.
+type MusicReturn struct {
+	// Track is the track that was played.
+	// Its Duration is the length to return, if its LengthValid is true.
+	Track Track
+	// Album is the album the track was played from.
+	Album Album
+	// Plays is the number of times the track was played.
+	Plays int
+}
+
+// MusicReturns aggregates tracklist items into music returns, merging repeat plays of the same track.
+// Library tracks are matched by ID, and others by title and artist, ignoring case.
+// The returns are sorted by play count, most played first, then by artist and title.
+// This consumes no API requests.
+func MusicReturns(items []TracklistItem) []MusicReturn {
+	var returns []MusicReturn
+	index := make(map[string]int)
+
+	for _, item := range items {
+		key := "id:" + strconv.FormatUint(item.ID, 10)
+		if item.ID == 0 {
+			key = "text:" + strings.ToLower(item.Title) + "\x00" + strings.ToLower(item.Artist)
+		}
+
+		if i, ok := index[key]; ok {
+			returns[i].Plays++
+			continue
+		}
+
+		index[key] = len(returns)
+		returns = append(returns, MusicReturn{
+			Track: item.Track,
+			Album: item.Album,
+			Plays: 1,
+		})
+	}
+
+	sort.SliceStable(returns, func(i, j int) bool {
+		a, b := returns[i], returns[j]
+		if a.Plays != b.Plays {
+			return a.Plays > b.Plays
+		}
+		if a.Track.Artist != b.Track.Artist {
+			return a.Track.Artist < b.Track.Artist
+		}
+		return a.Track.Title < b.Track.Title
+	})
+	return returns
+}
+
+// GetMusicReturns gets music returns for every track logged between start (inclusive) and end (exclusive).
+// This consumes the API requests of GetScheduleRange, plus one for each timeslot in the range.
+func (s *Session) GetMusicReturns(start, end time.Time) ([]MusicReturn, error) {
+	timeslots, err := s.GetScheduleRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []TracklistItem
+	for _, t := range timeslots {
+		tracklist, err := s.GetTrackListForTimeslot(int(t.TimeslotID))
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range tracklist {
+			// Timeslots at the edges of the range may have played tracks outside it.
+			if !item.Time.Before(start) && item.Time.Before(end) {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return MusicReturns(items), nil
+}
+
+// WriteMusicReturns writes returns to w as delimited text, with a header row.
+// Use ',' as comma for CSV, or '\t' for TSV.
+// Tracks whose length was missing or unparseable have an empty duration, and say why in their notes.
+// This consumes no API requests.
+func WriteMusicReturns(w io.Writer, returns []MusicReturn, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	if err := cw.Write(musicReturnsHeader); err != nil {
+		return err
+	}
+	for _, r := range returns {
+		duration, notes := "", ""
+		switch {
+		case r.Track.LengthValid:
+			duration = formatDuration(r.Track.Duration)
+		case r.Track.Length == "":
+			notes = "length missing"
+		default:
+			notes = fmt.Sprintf("unparseable length %q", r.Track.Length)
+		}
+
+		row := []string{
+			r.Track.Title,
+			r.Track.Artist,
+			r.Album.Title,
+			r.Album.RecordLabel,
+			strconv.Itoa(r.Plays),
+			duration,
+			notes,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
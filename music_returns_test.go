@@ -0,0 +1,49 @@
+package myradio_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+// TestWriteMusicReturns tests whether repeat plays are merged, and bad lengths flagged, in music returns.
+func TestWriteMusicReturns(t *testing.T) {
+	session, err := myradio.MockSession([]byte(`[
+		{"trackid": 0, "title": "Jenny", "artist": "Tommy Tutone", "length": "1 2 3", "starttime": "14/11/2016 00:00:00"},
+		{"trackid": 1, "title": "Africa", "artist": "Toto", "length": "00:04:55", "starttime": "14/11/2016 00:04:00"},
+		{"trackid": 0, "title": "jenny", "artist": "tommy tutone", "length": "1 2 3", "starttime": "14/11/2016 00:09:00"},
+		{"trackid": 1, "title": "Africa", "artist": "Toto", "length": "00:04:55", "starttime": "14/11/2016 00:13:00"},
+		{"trackid": 1, "title": "Africa", "artist": "Toto", "length": "00:04:55", "starttime": "14/11/2016 00:18:00"},
+		{"trackid": 0, "title": "Unknown", "artist": "Anon", "length": "", "starttime": "14/11/2016 00:23:00"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := session.GetTrackListForTimeslot(1)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	for i := range items {
+		items[i].Album = myradio.Album{Title: "Greatest Hits", RecordLabel: "URY Records"}
+	}
+
+	expected := "Title\tArtist\tAlbum\tLabel\tPlay Count\tDuration\tNotes\n" +
+		"Africa\tToto\tGreatest Hits\tURY Records\t3\t00:04:55\t\n" +
+		"Jenny\tTommy Tutone\tGreatest Hits\tURY Records\t2\t\t\"unparseable length \"\"1 2 3\"\"\"\n" +
+		"Unknown\tAnon\tGreatest Hits\tURY Records\t1\t\tlength missing\n"
+
+	returns := myradio.MusicReturns(items)
+	if r := returns[0]; !r.Track.LengthValid || r.Track.Duration != 4*time.Minute+55*time.Second {
+		t.Error("expected the most played track to keep its length, got:", r.Track.Duration, r.Track.LengthValid)
+	}
+
+	var buf bytes.Buffer
+	if err := myradio.WriteMusicReturns(&buf, returns, '\t'); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\n\ngot:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,148 @@
+package myradio
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// RDSRadioTextLength is the longest RDS RadioText message a receiver will show.
+	RDSRadioTextLength = 64
+	// DABLabelLength is the longest DAB dynamic label a receiver will show.
+	DABLabelLength = 128
+)
+
+// TrackEventKind is the type of things that can happen to a track on air.
+type TrackEventKind int
+
+const (
+	// TrackStart means the track has started playing.
+	TrackStart TrackEventKind = iota
+	// TrackEnd means the track has stopped playing.
+	TrackEnd
+)
+
+// String gets a human-readable name for the event kind.
+func (k TrackEventKind) String() string {
+	switch k {
+	case TrackStart:
+		return "start"
+	case TrackEnd:
+		return "end"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the event kind as its name, so that events read well as JSON.
+func (k TrackEventKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// TrackEvent reports a track starting or stopping on air.
+type TrackEvent struct {
+	// Kind says whether the track started or stopped.
+	Kind TrackEventKind `json:"kind"`
+	// Track is the track that started or stopped.
+	Track Track `json:"track"`
+	// Time is the wall-clock time at which the change was seen.
+	Time time.Time `json:"time"`
+	// FromJukebox is true if the track was played by the jukebox, rather than from a studio.
+	FromJukebox bool `json:"from_jukebox"`
+}
+
+// RadioText gets a description of the event's track for RDS or DAB text, cut down to at most max characters.
+// Use RDSRadioTextLength or DABLabelLength for max as appropriate.
+// A max of zero or less gives an empty string.
+func (e TrackEvent) RadioText(max int) string {
+	if max <= 0 {
+		return ""
+	}
+	text := []rune(e.Track.Artist + " - " + e.Track.Title)
+	if e.Track.Artist == "" {
+		text = []rune(e.Track.Title)
+	}
+	if len(text) <= max {
+		return string(text)
+	}
+	return string(text[:max])
+}
+
+// NowPlayingWatcher polls the now-playing track, reporting each track as it starts and stops.
+type NowPlayingWatcher struct {
+	session     *Session
+	interval    time.Duration
+	allowOffAir bool
+}
+
+// NewNowPlayingWatcher constructs a NowPlayingWatcher that polls every interval.
+// allowOffAir is as in GetNowPlaying.
+// An interval of zero or less means DefaultPollInterval.
+func (s *Session) NewNowPlayingWatcher(interval time.Duration, allowOffAir bool) *NowPlayingWatcher {
+	return &NowPlayingWatcher{session: s, interval: pollInterval(interval), allowOffAir: allowOffAir}
+}
+
+// Run polls the now-playing track until ctx is done, sending events on out as tracks start and stop.
+// Polls that see the same track as last time send nothing.
+// When a track changes, the end of the old track is sent before the start of the new one.
+// Run returns ctx's error once ctx is done, or the first error it gets from the API.
+// Each poll consumes one API request, plus one more to check the selector whenever a new track starts.
+func (w *NowPlayingWatcher) Run(ctx context.Context, out chan<- TrackEvent) error {
+	var last *TrackEvent
+
+	send := func(ev TrackEvent) error {
+		select {
+		case out <- ev:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return poll(ctx, func() (time.Duration, error) {
+		track, err := w.session.GetNowPlaying(w.allowOffAir)
+		if err != nil {
+			return 0, err
+		}
+		if last != nil && sameTrack(last.Track, track) {
+			return w.interval, nil
+		}
+
+		now := time.Now()
+		if last != nil {
+			end := *last
+			end.Kind = TrackEnd
+			end.Time = now
+			last = nil
+			if err := send(end); err != nil {
+				return 0, err
+			}
+		}
+
+		if track.ID == 0 && track.Title == "" {
+			// Nothing is playing.
+			return w.interval, nil
+		}
+
+		info, err := w.session.GetSelectorInfo()
+		if err != nil {
+			return 0, err
+		}
+		last = &TrackEvent{
+			Kind:        TrackStart,
+			Track:       track,
+			Time:        now,
+			FromJukebox: info != nil && info.Studio == StudioJukebox,
+		}
+		if err := send(*last); err != nil {
+			return 0, err
+		}
+
+		return w.interval, nil
+	})
+}
+
+// sameTrack checks whether a and b describe the same track.
+func sameTrack(a, b Track) bool {
+	return a.ID == b.ID && a.Title == b.Title && a.Artist == b.Artist
+}
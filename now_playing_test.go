@@ -0,0 +1,81 @@
+package myradio
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestNowPlayingWatcher tests whether a NowPlayingWatcher ignores repeated polls, ends each track before starting
+// the next, and sends nothing to start when nothing is playing.
+// It does not test the API endpoint.
+func TestNowPlayingWatcher(t *testing.T) {
+	const (
+		a       = `{"track": {"trackid": 1, "title": "Toast", "artist": "Tommy"}}`
+		b       = `{"track": {"trackid": 2, "title": "Jam", "artist": "Tutone"}}`
+		nothing = `{"track": {}}`
+	)
+	session, _ := scriptedSession(map[string][]string{
+		"/track/nowplaying": {a, a, b, nothing, b},
+		"/selector/query":   {`{"studio": 3, "lock": 0, "selectedfrom": 3, "power": 3}`},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan TrackEvent)
+	done := make(chan error)
+	go func() {
+		done <- session.NewNowPlayingWatcher(time.Millisecond, false).Run(ctx, events)
+	}()
+
+	expected := []struct {
+		kind    TrackEventKind
+		trackID uint64
+	}{
+		{TrackStart, 1},
+		{TrackEnd, 1},
+		{TrackStart, 2},
+		{TrackEnd, 2},
+		{TrackStart, 2},
+	}
+	for i, e := range expected {
+		ev := <-events
+		if ev.Kind != e.kind || ev.Track.ID != e.trackID {
+			t.Errorf("event %d: expected %v of track %d, got %v of track %d", i, e.kind, e.trackID, ev.Kind, ev.Track.ID)
+		}
+		if !ev.FromJukebox {
+			t.Errorf("event %d: expected track to be from the jukebox", i)
+		}
+	}
+
+	// The last track repeats forever, so there should be no further events.
+	select {
+	case ev := <-events:
+		t.Error("unexpected event:", ev)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Error("expected:", context.Canceled, "got:", err)
+	}
+}
+
+// TestTrackEventRadioText tests whether RadioText formats and truncates track descriptions.
+func TestTrackEventRadioText(t *testing.T) {
+	cases := []struct {
+		track    Track
+		max      int
+		expected string
+	}{
+		{Track{Title: "Toast", Artist: "Tommy"}, RDSRadioTextLength, "Tommy - Toast"},
+		{Track{Title: "Toast"}, RDSRadioTextLength, "Toast"},
+		{Track{Title: "Café au lait", Artist: "Tommy"}, 12, "Tommy - Café"},
+		{Track{Title: "Toast", Artist: "Tommy"}, 0, ""},
+		{Track{Title: "Toast", Artist: "Tommy"}, -1, ""},
+	}
+	for _, c := range cases {
+		if got := (TrackEvent{Track: c.track}).RadioText(c.max); got != c.expected {
+			t.Errorf("RadioText(%d) of %v: expected %q, got %q", c.max, c.track, c.expected, got)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package myradio
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/UniversityRadioYork/myradio-go/api"
@@ -46,3 +50,85 @@ func (s *Session) GetAllOfficerPositions(mixins []string) (positions []OfficerPo
 
 	return
 }
+
+// AssignOfficer appoints user to position, with effect from the given time, returning the new Officer.
+// This consumes one API request.
+func (s *Session) AssignOfficer(position OfficerPosition, user User, from time.Time) (officer *Officer, err error) {
+	if position.OfficerID == 0 || user.MemberID == 0 {
+		return nil, errors.New("AssignOfficer: position and user must both have IDs")
+	}
+
+	err = s.post(fmt.Sprintf("/officer/%d/assign", position.OfficerID), map[string][]string{
+		"memberid": {strconv.Itoa(user.MemberID)},
+		"from":     {strconv.FormatInt(from.Unix(), 10)},
+	}).Into(&officer)
+	if err == nil && officer != nil {
+		officer.From = time.Unix(officer.FromRaw, 0)
+	}
+	return
+}
+
+// StandDownOfficer ends officer's appointment at the given time.
+// This consumes one API request.
+func (s *Session) StandDownOfficer(officer Officer, till time.Time) error {
+	if officer.MemberOfficerID == 0 {
+		return errors.New("StandDownOfficer: officer has no member officer ID")
+	}
+	if officer.FromRaw != 0 && till.Unix() < officer.FromRaw {
+		return fmt.Errorf("StandDownOfficer: can't stand down at %v, before the appointment at %v", till, time.Unix(officer.FromRaw, 0))
+	}
+
+	_, err := s.post(fmt.Sprintf("/officer/standdown/%d", officer.MemberOfficerID), map[string][]string{
+		"till": {strconv.FormatInt(till.Unix(), 10)},
+	}).JSON()
+	return err
+}
+
+// CreateOfficerPosition creates a new officer position in team, using the name, alias, ordering, description and type
+// of position, and returns the created position.
+// The type must be one of MyRadio's position types: "h" for a head of team, "a" for an assistant head, "o" for an
+// officer, or "m" for a team member.
+// This consumes one API request.
+func (s *Session) CreateOfficerPosition(team Team, position OfficerPosition) (created *OfficerPosition, err error) {
+	if team.TeamID == 0 {
+		return nil, errors.New("CreateOfficerPosition: team has no ID")
+	}
+	if strings.TrimSpace(position.Name) == "" {
+		return nil, errors.New("CreateOfficerPosition: position has no name")
+	}
+	if !isOfficerType(position.Type) {
+		return nil, fmt.Errorf("CreateOfficerPosition: %q is not a known position type", position.Type)
+	}
+
+	err = s.post("/officer/create", map[string][]string{
+		"teamid":      {strconv.FormatUint(uint64(team.TeamID), 10)},
+		"name":        {position.Name},
+		"alias":       {position.Alias},
+		"ordering":    {strconv.Itoa(position.Ordering)},
+		"description": {position.Description},
+		"type":        {position.Type},
+	}).Into(&created)
+	return
+}
+
+// isOfficerType checks whether t is one of MyRadio's officer position types.
+func isOfficerType(t string) bool {
+	switch t {
+	case "h", "a", "o", "m":
+		return true
+	default:
+		return false
+	}
+}
+
+// RetireOfficerPosition marks position as no longer in use.
+// Its history is kept, but nobody can be assigned to it afterwards.
+// This consumes one API request.
+func (s *Session) RetireOfficerPosition(position OfficerPosition) error {
+	if position.OfficerID == 0 {
+		return errors.New("RetireOfficerPosition: position has no ID")
+	}
+
+	_, err := s.post(fmt.Sprintf("/officer/%d/retire", position.OfficerID), map[string][]string{}).JSON()
+	return err
+}
@@ -0,0 +1,141 @@
+package myradio_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+// TestOfficerWriteValidation tests whether the officer management methods reject requests they can't send.
+// It does not test the API endpoint.
+func TestOfficerWriteValidation(t *testing.T) {
+	session, err := myradio.MockSession([]byte(`null`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	position := myradio.OfficerPosition{OfficerID: 2, Name: "Station Manager", Type: "h"}
+	user := myradio.User{MemberID: 10}
+	officer := myradio.Officer{MemberOfficerID: 1, FromRaw: 1479081600}
+	appointed := time.Unix(officer.FromRaw, 0)
+
+	cases := []struct {
+		name string
+		call func() error
+		ok   bool
+	}{
+		{"assign", func() error { _, err := session.AssignOfficer(position, user, appointed); return err }, true},
+		{"assign without position ID", func() error {
+			_, err := session.AssignOfficer(myradio.OfficerPosition{}, user, appointed)
+			return err
+		}, false},
+		{"assign without member ID", func() error {
+			_, err := session.AssignOfficer(position, myradio.User{}, appointed)
+			return err
+		}, false},
+		{"stand down", func() error { return session.StandDownOfficer(officer, appointed.Add(time.Hour)) }, true},
+		{"stand down without member officer ID", func() error {
+			return session.StandDownOfficer(myradio.Officer{}, appointed)
+		}, false},
+		{"stand down before appointment", func() error {
+			return session.StandDownOfficer(officer, appointed.Add(-time.Hour))
+		}, false},
+		{"create", func() error {
+			_, err := session.CreateOfficerPosition(myradio.Team{TeamID: 1}, position)
+			return err
+		}, true},
+		{"create without type", func() error {
+			_, err := session.CreateOfficerPosition(myradio.Team{TeamID: 1}, myradio.OfficerPosition{Name: "Station Manager"})
+			return err
+		}, false},
+		{"create with unknown type", func() error {
+			_, err := session.CreateOfficerPosition(myradio.Team{TeamID: 1}, myradio.OfficerPosition{Name: "Station Manager", Type: "x"})
+			return err
+		}, false},
+		{"create without team ID", func() error {
+			_, err := session.CreateOfficerPosition(myradio.Team{}, position)
+			return err
+		}, false},
+		{"create without name", func() error {
+			_, err := session.CreateOfficerPosition(myradio.Team{TeamID: 1}, myradio.OfficerPosition{Name: " "})
+			return err
+		}, false},
+		{"retire", func() error { return session.RetireOfficerPosition(position) }, true},
+		{"retire without position ID", func() error {
+			return session.RetireOfficerPosition(myradio.OfficerPosition{})
+		}, false},
+	}
+	for _, c := range cases {
+		if err := c.call(); (err == nil) != c.ok {
+			t.Errorf("%s: expected ok=%v, got error: %v", c.name, c.ok, err)
+		}
+	}
+}
+
+// TestAssignOfficer tests the unmarshalling of the Officer returned by AssignOfficer.
+// It does not test the API endpoint.
+func TestAssignOfficer(t *testing.T) {
+	session, err := myradio.MockSession([]byte(`{
+		"user": {"memberid": 10, "fname": "John", "sname": "Smith"},
+		"from": 1479081600,
+		"memberofficerid": 1,
+		"position": {"officerid": 2, "name": "Station Manager", "ordering": 2}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &myradio.Officer{
+		User:            myradio.User{MemberID: 10, Fname: "John", Sname: "Smith"},
+		From:            time.Unix(1479081600, 0),
+		FromRaw:         1479081600,
+		MemberOfficerID: 1,
+		Position:        myradio.OfficerPosition{OfficerID: 2, Name: "Station Manager", Ordering: 2},
+	}
+	officer, err := session.AssignOfficer(expected.Position, expected.User, expected.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(officer, expected) {
+		t.Errorf("expected:\n%v\n\ngot:\n%v", expected, officer)
+	}
+}
+
+// TestCreateOfficerPosition tests the unmarshalling of the OfficerPosition returned by CreateOfficerPosition.
+// It does not test the API endpoint.
+func TestCreateOfficerPosition(t *testing.T) {
+	session, err := myradio.MockSession([]byte(`{
+		"officerid": 5,
+		"name": "Music Librarian",
+		"alias": "music.librarian",
+		"team": {"teamid": 4, "name": "Music", "alias": "music", "ordering": 20},
+		"ordering": 3,
+		"description": "Looks after the library",
+		"status": "c",
+		"type": "o"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	team := myradio.Team{TeamID: 4, Name: "Music", Alias: "music", Ordering: 20}
+	expected := &myradio.OfficerPosition{
+		OfficerID:   5,
+		Name:        "Music Librarian",
+		Alias:       "music.librarian",
+		Team:        team,
+		Ordering:    3,
+		Description: "Looks after the library",
+		Status:      "c",
+		Type:        "o",
+	}
+	position, err := session.CreateOfficerPosition(team, myradio.OfficerPosition{Name: "Music Librarian", Type: "o"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(position, expected) {
+		t.Errorf("expected:\n%v\n\ngot:\n%v", expected, position)
+	}
+}
@@ -0,0 +1,275 @@
+package myradio
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sameAppointmentSlack is how far apart two sources' start times for one appointment may be.
+// Officerships only give dates, while position histories give exact times, so the two rarely agree exactly.
+const sameAppointmentSlack = 24 * time.Hour
+
+// OfficerTerm is one continuous period for which a member held an officer position.
+type OfficerTerm struct {
+	// PositionID and PositionName identify the officer position.
+	PositionID   int
+	PositionName string
+	// TeamID is the ID of the team the position belongs to.
+	TeamID uint
+	// MemberID and MemberName identify the member who held the position.
+	MemberID   int
+	MemberName string
+	// MemberOfficerID is the ID of the appointment, if any source gave it, and 0 otherwise.
+	MemberOfficerID int
+	// From is the time at which the member took up the position.
+	From time.Time
+	// To is the time at which the member stood down, or the zero time if they still hold the position.
+	To time.Time
+}
+
+// IsCurrent checks whether the term is still ongoing.
+func (t OfficerTerm) IsCurrent() bool {
+	return t.To.IsZero()
+}
+
+// Contains checks whether the position was held during this term at time d.
+func (t OfficerTerm) Contains(d time.Time) bool {
+	return !d.Before(t.From) && (t.IsCurrent() || d.Before(t.To))
+}
+
+// OfficerTimeline merges officership data from MyRadio's various endpoints into one set of terms.
+// The zero OfficerTimeline is empty and ready to use.
+type OfficerTimeline struct {
+	// Terms holds every term in the timeline, ordered by start time.
+	Terms []OfficerTerm
+}
+
+// GetOfficerTimeline builds an OfficerTimeline from the history of every officer position.
+// Further data, such as from GetUserOfficerships, can be merged in afterwards.
+// This consumes one API request.
+func (s *Session) GetOfficerTimeline() (*OfficerTimeline, error) {
+	positions, err := s.GetAllOfficerPositions([]string{"history"})
+	if err != nil {
+		return nil, err
+	}
+	tl := &OfficerTimeline{}
+	tl.AddPositions(positions)
+	return tl, nil
+}
+
+// AddPositions merges the histories of the given positions into the timeline.
+// Positions fetched without the history mixin add nothing, and history entries with no member or start time are
+// skipped.
+func (tl *OfficerTimeline) AddPositions(positions []OfficerPosition) {
+	for _, p := range positions {
+		for _, h := range p.History {
+			if h.User.MemberID == 0 || h.FromRaw == 0 {
+				continue
+			}
+			t := OfficerTerm{
+				PositionID:      p.OfficerID,
+				PositionName:    p.Name,
+				TeamID:          p.Team.TeamID,
+				MemberID:        h.User.MemberID,
+				MemberName:      userFullName(h.User),
+				MemberOfficerID: h.MemberOfficerID,
+				From:            time.Unix(h.FromRaw, 0),
+			}
+			// A zero end time means the member hasn't stood down.
+			if h.ToRaw != 0 {
+				t.To = time.Unix(h.ToRaw, 0)
+			}
+			tl.add(t)
+		}
+	}
+}
+
+// AddOfficerships merges the officerships of user, as from GetUserOfficerships, into the timeline.
+// Officerships with no start date are skipped.
+func (tl *OfficerTimeline) AddOfficerships(user User, officerships []Officership) {
+	if user.MemberID == 0 {
+		return
+	}
+	for _, o := range officerships {
+		if o.FromDateRaw == "" {
+			continue
+		}
+		tl.add(OfficerTerm{
+			PositionID:   int(o.OfficerId),
+			PositionName: o.OfficerName,
+			TeamID:       o.TeamId,
+			MemberID:     user.MemberID,
+			MemberName:   userFullName(user),
+			From:         o.FromDate,
+			To:           o.TillDate,
+		})
+	}
+}
+
+// AddOfficers merges the current officers of a team, as from GetTeamWithOfficers or the GetTeam*Positions
+// methods, into the timeline.
+// Vacant positions, which come back as officers with no member or start time, are skipped.
+func (tl *OfficerTimeline) AddOfficers(officers []Officer) {
+	for _, o := range officers {
+		if o.User.MemberID == 0 || o.FromRaw == 0 {
+			continue
+		}
+		tl.add(OfficerTerm{
+			PositionID:      o.Position.OfficerID,
+			PositionName:    o.Position.Name,
+			TeamID:          o.Position.Team.TeamID,
+			MemberID:        o.User.MemberID,
+			MemberName:      userFullName(o.User),
+			MemberOfficerID: int(o.MemberOfficerID),
+			From:            time.Unix(o.FromRaw, 0),
+		})
+	}
+}
+
+// add merges t into the timeline, combining it with any term that records the same appointment.
+func (tl *OfficerTimeline) add(t OfficerTerm) {
+	for i := range tl.Terms {
+		if tl.Terms[i].sameAppointment(t) {
+			tl.Terms[i].merge(t)
+			tl.sort()
+			return
+		}
+	}
+
+	tl.Terms = append(tl.Terms, t)
+	tl.sort()
+}
+
+// sort orders the terms by start time, with ties broken by position then member.
+func (tl *OfficerTimeline) sort() {
+	sort.SliceStable(tl.Terms, func(i, j int) bool {
+		a, b := tl.Terms[i], tl.Terms[j]
+		if !a.From.Equal(b.From) {
+			return a.From.Before(b.From)
+		}
+		if a.PositionID != b.PositionID {
+			return a.PositionID < b.PositionID
+		}
+		return a.MemberID < b.MemberID
+	})
+}
+
+// sameAppointment checks whether t and u are records of the same appointment from different sources.
+func (t *OfficerTerm) sameAppointment(u OfficerTerm) bool {
+	if t.PositionID != u.PositionID || t.MemberID != u.MemberID {
+		return false
+	}
+	if t.MemberOfficerID != 0 && u.MemberOfficerID != 0 {
+		return t.MemberOfficerID == u.MemberOfficerID
+	}
+	d := t.From.Sub(u.From)
+	return -sameAppointmentSlack <= d && d <= sameAppointmentSlack
+}
+
+// merge fills in t with anything u knows about the same appointment that t doesn't.
+// Exact start times win over dates, and a known end wins over an open term.
+func (t *OfficerTerm) merge(u OfficerTerm) {
+	if t.PositionName == "" {
+		t.PositionName = u.PositionName
+	}
+	if t.TeamID == 0 {
+		t.TeamID = u.TeamID
+	}
+	if t.MemberName == "" {
+		t.MemberName = u.MemberName
+	}
+	if t.MemberOfficerID == 0 {
+		t.MemberOfficerID = u.MemberOfficerID
+	}
+	if isMidnightUTC(t.From) && !isMidnightUTC(u.From) {
+		t.From = u.From
+	}
+	if t.To.IsZero() || !u.To.IsZero() && isMidnightUTC(t.To) && !isMidnightUTC(u.To) {
+		t.To = u.To
+	}
+}
+
+// isMidnightUTC checks whether t looks like a bare date, rather than an exact time.
+func isMidnightUTC(t time.Time) bool {
+	return t.Equal(t.UTC().Truncate(24 * time.Hour))
+}
+
+// HeldBy gets the terms of everyone holding the position with the given ID at time d.
+// Positions may have more than one holder at once.
+// This consumes no API requests.
+func (tl *OfficerTimeline) HeldBy(positionID int, d time.Time) []OfficerTerm {
+	var terms []OfficerTerm
+	for _, t := range tl.Terms {
+		if t.PositionID == positionID && t.Contains(d) {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// ForPosition gets every term of the position with the given ID, oldest first.
+// This consumes no API requests.
+func (tl *OfficerTimeline) ForPosition(positionID int) []OfficerTerm {
+	var terms []OfficerTerm
+	for _, t := range tl.Terms {
+		if t.PositionID == positionID {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// ForMember gets every term served by the member with the given ID, oldest first.
+// This consumes no API requests.
+func (tl *OfficerTimeline) ForMember(memberID int) []OfficerTerm {
+	var terms []OfficerTerm
+	for _, t := range tl.Terms {
+		if t.MemberID == memberID {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// officerTimelineHeader is the header row of committee history CSV files.
+var officerTimelineHeader = []string{"Position ID", "Position", "Team ID", "Member ID", "Member", "From", "To"}
+
+// WriteCSV writes every term in the timeline to w as CSV, one row per term, oldest first.
+// Dates are written as YYYY-MM-DD in UTC; ongoing terms have an empty To column.
+// This consumes no API requests.
+func (tl *OfficerTimeline) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(officerTimelineHeader); err != nil {
+		return err
+	}
+	for _, t := range tl.Terms {
+		to := ""
+		if !t.IsCurrent() {
+			to = t.To.UTC().Format("2006-01-02")
+		}
+		row := []string{
+			strconv.Itoa(t.PositionID),
+			t.PositionName,
+			strconv.FormatUint(uint64(t.TeamID), 10),
+			strconv.Itoa(t.MemberID),
+			t.MemberName,
+			t.From.UTC().Format("2006-01-02"),
+			to,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// userFullName gets the first name and surname of u, separated by a space.
+func userFullName(u User) string {
+	return strings.TrimSpace(u.Fname + " " + u.Sname)
+}
@@ -0,0 +1,85 @@
+package myradio_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+// TestOfficerTimeline tests whether OfficerTimeline merges officerships and position histories into one set of terms.
+func TestOfficerTimeline(t *testing.T) {
+	jo := myradio.User{MemberID: 10, Fname: "Jo", Sname: "Bloggs"}
+	sam := myradio.User{MemberID: 11, Fname: "Sam", Sname: "Smith"}
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	var position myradio.OfficerPosition
+	position.OfficerID = 2
+	position.Name = "Station Manager"
+	position.History = make([]struct {
+		User            myradio.User
+		From            time.Time
+		FromRaw         int64 `json:"from"`
+		To              time.Time
+		ToRaw           int64 `json:"to"`
+		MemberOfficerID int
+	}, 2)
+	position.History[0].User = jo
+	position.History[0].FromRaw = date(2015, time.June, 1).Add(14 * time.Hour).Unix()
+	position.History[0].ToRaw = date(2016, time.June, 1).Add(15 * time.Hour).Unix()
+	position.History[0].MemberOfficerID = 1
+	position.History[1].User = sam
+	position.History[1].FromRaw = date(2016, time.June, 1).Add(15 * time.Hour).Unix()
+	position.History[1].MemberOfficerID = 2
+
+	var tl myradio.OfficerTimeline
+	tl.AddPositions([]myradio.OfficerPosition{position})
+	var officerships []myradio.Officership
+	err := json.Unmarshal([]byte(`[
+		{"officerid": "2", "officer_name": "Station Manager", "teamid": "0", "from_date": "2015-06-01", "till_date": "2016-06-01"},
+		{"officerid": "3", "officer_name": "Head of Music", "teamid": "4", "from_date": "2016-06-01"},
+		{"officerid": "6", "officer_name": "Webmaster", "teamid": "4"}
+	]`), &officerships)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tl.AddOfficerships(jo, officerships)
+
+	// Vacant positions come back as officers with no member or start time, and shouldn't become terms.
+	var vacancy myradio.Officer
+	vacancy.Position.OfficerID = 5
+	vacancy.Position.Name = "Music Librarian"
+	tl.AddOfficers([]myradio.Officer{vacancy})
+	if held := tl.HeldBy(5, date(2017, time.January, 1)); len(held) != 0 {
+		t.Errorf("expected Music Librarian to be vacant, got: %v", held)
+	}
+
+	if n := len(tl.Terms); n != 3 {
+		t.Fatalf("expected 3 terms, got %d: %v", n, tl.Terms)
+	}
+	if held := tl.HeldBy(2, date(2016, time.January, 1)); len(held) != 1 || held[0].MemberID != jo.MemberID {
+		t.Errorf("expected Jo to be Station Manager on 2016-01-01, got: %v", held)
+	}
+	if held := tl.HeldBy(2, date(2017, time.January, 1)); len(held) != 1 || held[0].MemberID != sam.MemberID {
+		t.Errorf("expected Sam to be Station Manager on 2017-01-01, got: %v", held)
+	}
+	if terms := tl.ForMember(jo.MemberID); len(terms) != 2 {
+		t.Errorf("expected Jo to have 2 terms, got: %v", terms)
+	}
+
+	expected := "Position ID,Position,Team ID,Member ID,Member,From,To\n" +
+		"2,Station Manager,0,10,Jo Bloggs,2015-06-01,2016-06-01\n" +
+		"3,Head of Music,4,10,Jo Bloggs,2016-06-01,\n" +
+		"2,Station Manager,0,11,Sam Smith,2016-06-01,\n"
+	var buf bytes.Buffer
+	if err := tl.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\n\ngot:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,229 @@
+package myradio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// OrgChartLevel identifies the level of a node in an OrgChart.
+type OrgChartLevel string
+
+const (
+	// LevelTeam is a station committee team.
+	LevelTeam OrgChartLevel = "team"
+	// LevelHead is a head-of-team position.
+	LevelHead OrgChartLevel = "head"
+	// LevelAssistantHead is an assistant-head-of-team position.
+	LevelAssistantHead OrgChartLevel = "assistanthead"
+	// LevelOfficer is any other officer position.
+	LevelOfficer OrgChartLevel = "officer"
+)
+
+// OrgChartNode is a team or officer position in an OrgChart.
+type OrgChartNode struct {
+	// Level is the level of the node.
+	Level OrgChartLevel `json:"level"`
+	// Name and Alias are the name and alias of the team or position.
+	Name  string `json:"name"`
+	Alias string `json:"alias,omitempty"`
+	// Holders holds the names of the members holding a position, and is empty for teams and vacant positions.
+	Holders []string `json:"holders,omitempty"`
+	// Children holds the nodes below this one, in order.
+	Children []*OrgChartNode `json:"children,omitempty"`
+}
+
+// OrgChart is the structure of the station committee, as a tree per team.
+// Each team's head positions sit under the team, its assistant heads under its first head, and its other officers
+// under its first assistant head.
+// Where a team lacks a level, the positions below it move up to the nearest level it has.
+type OrgChart struct {
+	// Teams holds one tree per team, ordered by Team.Ordering.
+	Teams []*OrgChartNode `json:"teams"`
+}
+
+// TeamPositions holds the positions in one team, as from the GetTeam*Positions methods.
+type TeamPositions struct {
+	Heads, AssistantHeads, Officers []Officer
+}
+
+// NewOrgChart builds an OrgChart from teams and, keyed by team ID, their positions.
+// Teams are ordered by Team.Ordering and positions by OfficerPosition.Ordering, with ties broken by name.
+// This consumes no API requests.
+func NewOrgChart(teams []Team, positions map[uint]TeamPositions) *OrgChart {
+	sorted := append([]Team(nil), teams...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Ordering != sorted[j].Ordering {
+			return sorted[i].Ordering < sorted[j].Ordering
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	chart := &OrgChart{}
+	for _, t := range sorted {
+		node := &OrgChartNode{Level: LevelTeam, Name: t.Name, Alias: t.Alias}
+		parent := node
+		ps := positions[t.TeamID]
+		for _, level := range []struct {
+			level    OrgChartLevel
+			officers []Officer
+		}{
+			{LevelHead, ps.Heads},
+			{LevelAssistantHead, ps.AssistantHeads},
+			{LevelOfficer, ps.Officers},
+		} {
+			children := orgChartPositions(level.level, level.officers)
+			if len(children) == 0 {
+				continue
+			}
+			parent.Children = append(parent.Children, children...)
+			parent = children[0]
+		}
+		chart.Teams = append(chart.Teams, node)
+	}
+	return chart
+}
+
+// orgChartPositions groups officers by position into nodes at the given level, in position order.
+func orgChartPositions(level OrgChartLevel, officers []Officer) []*OrgChartNode {
+	type position struct {
+		node     *OrgChartNode
+		ordering int
+	}
+	var ps []position
+	byID := make(map[int]*OrgChartNode)
+	for _, o := range officers {
+		node, ok := byID[o.Position.OfficerID]
+		if !ok {
+			node = &OrgChartNode{Level: level, Name: o.Position.Name, Alias: o.Position.Alias}
+			byID[o.Position.OfficerID] = node
+			ps = append(ps, position{node: node, ordering: o.Position.Ordering})
+		}
+		if name := userFullName(o.User); name != "" {
+			node.Holders = append(node.Holders, name)
+		}
+	}
+
+	sort.SliceStable(ps, func(i, j int) bool {
+		if ps[i].ordering != ps[j].ordering {
+			return ps[i].ordering < ps[j].ordering
+		}
+		return ps[i].node.Name < ps[j].node.Name
+	})
+	nodes := make([]*OrgChartNode, len(ps))
+	for i, p := range ps {
+		nodes[i] = p.node
+	}
+	return nodes
+}
+
+// GetOrgChart builds an OrgChart of the current station committee.
+// This consumes one API request, plus three for each team.
+func (s *Session) GetOrgChart() (*OrgChart, error) {
+	teams, err := s.GetCurrentTeams()
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(map[uint]TeamPositions)
+	for _, t := range teams {
+		var ps TeamPositions
+		if ps.Heads, err = s.GetTeamHeadPositions(int(t.TeamID), nil); err != nil {
+			return nil, err
+		}
+		if ps.AssistantHeads, err = s.GetTeamAssistantHeadPositions(int(t.TeamID), nil); err != nil {
+			return nil, err
+		}
+		if ps.Officers, err = s.GetTeamOfficerPositions(int(t.TeamID), nil); err != nil {
+			return nil, err
+		}
+		positions[t.TeamID] = ps
+	}
+	return NewOrgChart(teams, positions), nil
+}
+
+// WriteJSON writes the chart to w as indented JSON.
+// This consumes no API requests.
+func (c *OrgChart) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
+
+// WriteDOT writes the chart to w as a Graphviz DOT digraph, with one box per team or position.
+// This consumes no API requests.
+func (c *OrgChart) WriteDOT(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("digraph committee {\n")
+	sb.WriteString("\trankdir=TB;\n")
+	sb.WriteString("\tnode [shape=box];\n")
+
+	n := 0
+	var walk func(node *OrgChartNode) int
+	walk = func(node *OrgChartNode) int {
+		id := n
+		n++
+
+		label := node.Name
+		if node.Level != LevelTeam {
+			label += "\n" + orgChartHolders(node)
+		}
+		style := ""
+		if node.Level == LevelTeam {
+			style = ", style=bold"
+		}
+		fmt.Fprintf(&sb, "\tn%d [label=%s%s];\n", id, dotQuote(label), style)
+
+		for _, child := range node.Children {
+			fmt.Fprintf(&sb, "\tn%d -> n%d;\n", id, walk(child))
+		}
+		return id
+	}
+	for _, t := range c.Teams {
+		walk(t)
+	}
+
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteMarkdown writes the chart to w as Markdown, with a heading per team and a nested list of its positions.
+// This consumes no API requests.
+func (c *OrgChart) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	var walk func(node *OrgChartNode, depth int)
+	walk = func(node *OrgChartNode, depth int) {
+		fmt.Fprintf(&sb, "%s- **%s**: %s\n", strings.Repeat("  ", depth), node.Name, orgChartHolders(node))
+		for _, child := range node.Children {
+			walk(child, depth+1)
+		}
+	}
+
+	for i, t := range c.Teams {
+		if 0 < i {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "## %s\n\n", t.Name)
+		for _, child := range t.Children {
+			walk(child, 0)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// orgChartHolders lists the holders of the position at node, or says that it is vacant.
+func orgChartHolders(node *OrgChartNode) string {
+	if len(node.Holders) == 0 {
+		return "Vacant"
+	}
+	return strings.Join(node.Holders, ", ")
+}
+
+// dotQuote quotes s as a DOT string, turning newlines into DOT line breaks.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
@@ -0,0 +1,92 @@
+package myradio_test
+
+import (
+	"bytes"
+	"testing"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+// orgChartFixture builds a small two-team org chart.
+func orgChartFixture() *myradio.OrgChart {
+	officer := func(positionID, ordering int, name, fname string) myradio.Officer {
+		var o myradio.Officer
+		if fname != "" {
+			o.User = myradio.User{Fname: fname, Sname: "Bloggs"}
+		}
+		o.Position.OfficerID = positionID
+		o.Position.Name = name
+		o.Position.Ordering = ordering
+		return o
+	}
+
+	teams := []myradio.Team{
+		{TeamID: 2, Name: "Music", Ordering: 20},
+		{TeamID: 1, Name: "Management", Ordering: 10},
+	}
+	positions := map[uint]myradio.TeamPositions{
+		1: {
+			Heads:          []myradio.Officer{officer(1, 1, "Station Manager", "Jo")},
+			AssistantHeads: []myradio.Officer{officer(2, 1, "Deputy \"Station\" Manager", "Sam")},
+		},
+		2: {
+			Heads: []myradio.Officer{officer(3, 1, "Head of Music", "Alex")},
+			Officers: []myradio.Officer{
+				officer(5, 2, "Music Librarian", ""),
+				officer(4, 1, "Playlist Officer", "Max"),
+				officer(4, 1, "Playlist Officer", "Kim"),
+			},
+		},
+	}
+	return myradio.NewOrgChart(teams, positions)
+}
+
+// TestOrgChartMarkdown tests whether an org chart nests and orders its positions.
+func TestOrgChartMarkdown(t *testing.T) {
+	expected := `## Management
+
+- **Station Manager**: Jo Bloggs
+  - **Deputy "Station" Manager**: Sam Bloggs
+
+## Music
+
+- **Head of Music**: Alex Bloggs
+  - **Playlist Officer**: Max Bloggs, Kim Bloggs
+  - **Music Librarian**: Vacant
+`
+	var buf bytes.Buffer
+	if err := orgChartFixture().WriteMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\n\ngot:\n%s", expected, buf.String())
+	}
+}
+
+// TestOrgChartDOT tests whether an org chart renders as DOT with escaped labels.
+func TestOrgChartDOT(t *testing.T) {
+	expected := `digraph committee {
+	rankdir=TB;
+	node [shape=box];
+	n0 [label="Management", style=bold];
+	n1 [label="Station Manager\nJo Bloggs"];
+	n2 [label="Deputy \"Station\" Manager\nSam Bloggs"];
+	n1 -> n2;
+	n0 -> n1;
+	n3 [label="Music", style=bold];
+	n4 [label="Head of Music\nAlex Bloggs"];
+	n5 [label="Playlist Officer\nMax Bloggs, Kim Bloggs"];
+	n4 -> n5;
+	n6 [label="Music Librarian\nVacant"];
+	n4 -> n6;
+	n3 -> n4;
+}
+`
+	var buf bytes.Buffer
+	if err := orgChartFixture().WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\n\ngot:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,267 @@
+package myradio
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Playlist represents a managed playlist, such as those the jukebox plays from.
+type Playlist struct {
+	ID          string `json:"playlistid"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Revision changes with every edit to the playlist.
+	// Edits must give the revision they were based on, so that they can't overwrite someone else's changes.
+	Revision int `json:"revisionid"`
+}
+
+// GetAllPlaylists retrieves all managed playlists.
+// This consumes one API request.
+func (s *Session) GetAllPlaylists() (playlists []Playlist, err error) {
+	err = s.get("/playlist/allitonesplaylists").Into(&playlists)
+	return
+}
+
+// GetPlaylist retrieves the managed playlist with the given ID.
+// This consumes one API request.
+func (s *Session) GetPlaylist(id string) (playlist *Playlist, err error) {
+	err = s.getf("/playlist/%s", id).Into(&playlist)
+	return
+}
+
+// GetPlaylistTracks retrieves the tracks of the managed playlist with the given ID, in playlist order.
+// This consumes one API request.
+func (s *Session) GetPlaylistTracks(id string) (tracks []Track, err error) {
+	err = s.getf("/playlist/%s/tracks", id).Into(&tracks)
+	return
+}
+
+// PlaylistEditKind is the type of edits that can be made to a playlist.
+type PlaylistEditKind int
+
+const (
+	// PlaylistAdd inserts a track at position To.
+	PlaylistAdd PlaylistEditKind = iota
+	// PlaylistRemove removes the track at position From.
+	PlaylistRemove
+	// PlaylistMove moves the track at position From to position To.
+	PlaylistMove
+)
+
+// String gets a human-readable name for the edit kind.
+func (k PlaylistEditKind) String() string {
+	switch k {
+	case PlaylistAdd:
+		return "add"
+	case PlaylistRemove:
+		return "remove"
+	case PlaylistMove:
+		return "move"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the edit kind as its name, which is how MyRadio expects it.
+func (k PlaylistEditKind) MarshalText() ([]byte, error) {
+	if k < PlaylistAdd || PlaylistMove < k {
+		return nil, fmt.Errorf("PlaylistEditKind: %d is not a known edit kind", int(k))
+	}
+	return []byte(k.String()), nil
+}
+
+// PlaylistEdit is a single edit to a playlist.
+// Positions count from 0, and refer to the playlist as it stands after all previous edits in the same batch.
+type PlaylistEdit struct {
+	Kind    PlaylistEditKind `json:"kind"`
+	TrackID uint64           `json:"trackid"`
+	From    int              `json:"from"`
+	To      int              `json:"to"`
+}
+
+// DiffPlaylist works out the edits that turn a playlist with tracks before into one with tracks after.
+// Both are lists of track IDs in playlist order.
+// Tracks kept in the same relative order aren't touched, so the edits only cover what actually changed.
+// This consumes no API requests.
+func DiffPlaylist(before, after []uint64) (edits []PlaylistEdit) {
+	keptBefore, keptAfter := lcsMarks(before, after)
+
+	// Any track dropped from before and added in after is moved, rather than removed and re-added.
+	dropped := make(map[uint64]int)
+	for i, id := range before {
+		if !keptBefore[i] {
+			dropped[id]++
+		}
+	}
+	moves := make(map[uint64]int)
+	for j, id := range after {
+		if !keptAfter[j] && moves[id] < dropped[id] {
+			moves[id]++
+		}
+	}
+
+	// pending marks the tracks in cur that are waiting to be moved.
+	cur := append([]uint64(nil), before...)
+	pending := make([]bool, len(cur))
+	for i := range pending {
+		pending[i] = !keptBefore[i]
+	}
+
+	// Remove from the back, so earlier positions stay put.
+	removals := make(map[uint64]int)
+	for id, n := range dropped {
+		removals[id] = n - moves[id]
+	}
+	for i := len(cur) - 1; 0 <= i; i-- {
+		if id := cur[i]; pending[i] && 0 < removals[id] {
+			removals[id]--
+			edits = append(edits, PlaylistEdit{Kind: PlaylistRemove, TrackID: id, From: i})
+			cur = append(cur[:i], cur[i+1:]...)
+			pending = append(pending[:i], pending[i+1:]...)
+		}
+	}
+
+	// Fill each position of after in turn.
+	// Everything before p in cur is either in its final place, or still waiting to be moved out of the way.
+	p := 0
+	for j, id := range after {
+		if keptAfter[j] {
+			for pending[p] {
+				p++
+			}
+			p++
+			continue
+		}
+
+		if p < len(cur) && pending[p] && cur[p] == id && 0 < moves[id] {
+			// A track waiting to be moved is already where it needs to be.
+			moves[id]--
+			pending[p] = false
+			p++
+			continue
+		}
+
+		if 0 < moves[id] {
+			if k := indexPending(cur, pending, id); 0 <= k {
+				moves[id]--
+				to := p
+				if k < p {
+					to--
+				}
+				edits = append(edits, PlaylistEdit{Kind: PlaylistMove, TrackID: id, From: k, To: to})
+				cur = append(cur[:k], cur[k+1:]...)
+				pending = append(pending[:k], pending[k+1:]...)
+				cur = insertTrackID(cur, to, id)
+				pending = append(pending[:to], append([]bool{false}, pending[to:]...)...)
+				p = to + 1
+				continue
+			}
+		}
+
+		edits = append(edits, PlaylistEdit{Kind: PlaylistAdd, TrackID: id, To: p})
+		cur = insertTrackID(cur, p, id)
+		pending = append(pending[:p], append([]bool{false}, pending[p:]...)...)
+		p++
+	}
+
+	return
+}
+
+// lcsMarks finds a longest common subsequence of a and b, marking which elements of each are in it.
+func lcsMarks(a, b []uint64) (inA, inB []bool) {
+	// lens[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lens := make([][]int, len(a)+1)
+	for i := range lens {
+		lens[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; 0 <= i; i-- {
+		for j := len(b) - 1; 0 <= j; j-- {
+			switch {
+			case a[i] == b[j]:
+				lens[i][j] = lens[i+1][j+1] + 1
+			case lens[i+1][j] < lens[i][j+1]:
+				lens[i][j] = lens[i][j+1]
+			default:
+				lens[i][j] = lens[i+1][j]
+			}
+		}
+	}
+
+	inA, inB = make([]bool, len(a)), make([]bool, len(b))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			inA[i], inB[j] = true, true
+			i++
+			j++
+		case lens[i+1][j] < lens[i][j+1]:
+			j++
+		default:
+			i++
+		}
+	}
+	return
+}
+
+// indexPending finds the index of a track with the given ID that is waiting to be moved, or -1 if there isn't one.
+func indexPending(ids []uint64, pending []bool, id uint64) int {
+	for k := range ids {
+		if pending[k] && ids[k] == id {
+			return k
+		}
+	}
+	return -1
+}
+
+// insertTrackID inserts id into ids at position at.
+func insertTrackID(ids []uint64, at int, id uint64) []uint64 {
+	return append(ids[:at], append([]uint64{id}, ids[at:]...)...)
+}
+
+// EditPlaylist applies edits to the managed playlist with the given ID, in order.
+// revision must be the playlist's Revision when the edits were worked out; if the playlist has changed since,
+// MyRadio refuses the whole batch, and the caller should fetch the playlist again and retry.
+// On success, it returns the playlist with its new revision.
+// This consumes one API request.
+func (s *Session) EditPlaylist(id string, revision int, edits []PlaylistEdit) (playlist *Playlist, err error) {
+	body, err := json.Marshal(edits)
+	if err != nil {
+		return
+	}
+
+	err = s.post(fmt.Sprintf("/playlist/%s/edit", id), map[string][]string{
+		"revisionid": {strconv.Itoa(revision)},
+		"edits":      {string(body)},
+	}).Into(&playlist)
+	return
+}
+
+// AddPlaylistTrack inserts the track with the given ID at position into the playlist p.
+// This consumes one API request.
+func (s *Session) AddPlaylistTrack(p Playlist, trackID uint64, position int) (*Playlist, error) {
+	return s.EditPlaylist(p.ID, p.Revision, []PlaylistEdit{{Kind: PlaylistAdd, TrackID: trackID, To: position}})
+}
+
+// RemovePlaylistTrack removes the track with the given ID at position from the playlist p.
+// This consumes one API request.
+func (s *Session) RemovePlaylistTrack(p Playlist, trackID uint64, position int) (*Playlist, error) {
+	return s.EditPlaylist(p.ID, p.Revision, []PlaylistEdit{{Kind: PlaylistRemove, TrackID: trackID, From: position}})
+}
+
+// MovePlaylistTrack moves the track with the given ID from one position to another in the playlist p.
+// This consumes one API request.
+func (s *Session) MovePlaylistTrack(p Playlist, trackID uint64, from, to int) (*Playlist, error) {
+	return s.EditPlaylist(p.ID, p.Revision, []PlaylistEdit{{Kind: PlaylistMove, TrackID: trackID, From: from, To: to}})
+}
+
+// SyncPlaylist edits the playlist p, whose tracks are currently before, so that its tracks become after.
+// Only the differences between before and after are sent; if there are none, p is returned unchanged.
+// This consumes one API request if there is anything to change, and none otherwise.
+func (s *Session) SyncPlaylist(p Playlist, before, after []uint64) (*Playlist, error) {
+	edits := DiffPlaylist(before, after)
+	if len(edits) == 0 {
+		return &p, nil
+	}
+	return s.EditPlaylist(p.ID, p.Revision, edits)
+}
@@ -0,0 +1,96 @@
+package myradio
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/UniversityRadioYork/myradio-go/api"
+)
+
+// applyPlaylistEdits applies edits to ids as MyRadio would.
+func applyPlaylistEdits(ids []uint64, edits []PlaylistEdit) []uint64 {
+	ids = append([]uint64(nil), ids...)
+	insert := func(at int, id uint64) {
+		ids = append(ids[:at], append([]uint64{id}, ids[at:]...)...)
+	}
+	for _, e := range edits {
+		switch e.Kind {
+		case PlaylistAdd:
+			insert(e.To, e.TrackID)
+		case PlaylistRemove:
+			ids = append(ids[:e.From], ids[e.From+1:]...)
+		case PlaylistMove:
+			ids = append(ids[:e.From], ids[e.From+1:]...)
+			insert(e.To, e.TrackID)
+		}
+	}
+	return ids
+}
+
+func TestDiffPlaylist(t *testing.T) {
+	tests := []struct {
+		before, after []uint64
+		nedits        int
+	}{
+		{[]uint64{1, 2, 3}, []uint64{1, 2, 3}, 0},
+		{[]uint64{}, []uint64{1, 2}, 2},
+		{[]uint64{1, 2, 3}, []uint64{}, 3},
+		{[]uint64{1, 2, 3, 4}, []uint64{1, 3, 4}, 1},
+		{[]uint64{1, 2, 3, 4}, []uint64{1, 2, 5, 3, 4}, 1},
+		{[]uint64{1, 2, 3, 4}, []uint64{4, 1, 2, 3}, 1},
+		{[]uint64{1, 2, 3, 4}, []uint64{2, 5, 4, 1}, 3},
+		{[]uint64{1, 1, 2}, []uint64{2, 1, 1, 1}, 2},
+	}
+
+	for _, test := range tests {
+		edits := DiffPlaylist(test.before, test.after)
+		if len(edits) != test.nedits {
+			t.Error("expected", test.nedits, "edits from", test.before, "to", test.after, "got:", edits)
+		}
+		got := applyPlaylistEdits(test.before, edits)
+		if len(got) != 0 || len(test.after) != 0 {
+			if !reflect.DeepEqual(got, test.after) {
+				t.Error("expected:", test.after, "got:", got, "from edits:", edits)
+			}
+		}
+	}
+}
+
+// TestEditPlaylist tests whether EditPlaylist posts its edits, and the revision they were based on, in MyRadio's format.
+// It does not test the API endpoint.
+func TestEditPlaylist(t *testing.T) {
+	session, requester := scriptedSession(map[string][]string{
+		"/playlist/ury-a/edit": {`{"playlistid": "ury-a", "title": "A List", "revisionid": 8}`},
+	})
+	edits := []PlaylistEdit{
+		{Kind: PlaylistAdd, TrackID: 1, To: 0},
+		{Kind: PlaylistMove, TrackID: 2, From: 3, To: 1},
+		{Kind: PlaylistRemove, TrackID: 3, From: 4},
+	}
+
+	playlist, err := session.EditPlaylist("ury-a", 7, edits)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if expected := (Playlist{ID: "ury-a", Title: "A List", Revision: 8}); playlist == nil || *playlist != expected {
+		t.Errorf("expected:\n%v\n\ngot:\n%v", expected, playlist)
+	}
+
+	sent := requester.sent()
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(sent))
+	}
+	rq := sent[0]
+	if rq.Endpoint != "/playlist/ury-a/edit" || rq.ReqType != api.PostReq {
+		t.Errorf("expected POST to /playlist/ury-a/edit, got %v to %s", rq.ReqType, rq.Endpoint)
+	}
+	expected := map[string][]string{
+		"revisionid": {"7"},
+		"edits": {`[{"kind":"add","trackid":1,"from":0,"to":0},` +
+			`{"kind":"move","trackid":2,"from":3,"to":1},` +
+			`{"kind":"remove","trackid":3,"from":4,"to":0}]`},
+	}
+	if !reflect.DeepEqual(rq.Params, expected) {
+		t.Errorf("expected:\n%v\n\ngot:\n%v", expected, rq.Params)
+	}
+}
@@ -0,0 +1,170 @@
+package myradio
+
+import (
+	"sort"
+	"time"
+)
+
+// BroadcastHours gives the part of each day during which the station expects live programming.
+// Start and End are wall-clock times of day, given as offsets from midnight, and should lie within a single day.
+// They keep to the clock on days when it changes, so 8*time.Hour always means 08:00 local time.
+// If End is at or before Start, the broadcast hours run past midnight into the next day.
+type BroadcastHours struct {
+	Start, End time.Duration
+}
+
+// at reports whether t falls within broadcast hours, and the next time after t at which that changes.
+func (h BroadcastHours) at(t time.Time) (on bool, next time.Time) {
+	// Broadcast hours can start on the previous day and run into this one, so check either side of today.
+	for d := -1; d <= 1; d++ {
+		start := wallClock(t, d, h.Start)
+		end := wallClock(t, d, h.End)
+		if h.End <= h.Start {
+			end = wallClock(t, d+1, h.End)
+		}
+
+		if !t.Before(start) && t.Before(end) {
+			on = true
+		}
+		for _, b := range []time.Time{start, end} {
+			if b.After(t) && (next.IsZero() || b.Before(next)) {
+				next = b
+			}
+		}
+	}
+	return
+}
+
+// wallClock gets the time that the clock reads offset past midnight, days days after the day of t, in t's location.
+func wallClock(t time.Time, days int, offset time.Duration) time.Time {
+	return time.Date(
+		t.Year(), t.Month(), t.Day()+days,
+		int(offset/time.Hour), int(offset%time.Hour/time.Minute), int(offset%time.Minute/time.Second),
+		int(offset%time.Second), t.Location(),
+	)
+}
+
+// SchedulePeriod is a span of time on the schedule, from Start (inclusive) to End (exclusive).
+type SchedulePeriod struct {
+	Start, End time.Time
+}
+
+// Duration gets the length of the period.
+func (p SchedulePeriod) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// ScheduleOverlap is a period during which two timeslots are scheduled at once.
+// First is the timeslot that starts earlier.
+type ScheduleOverlap struct {
+	SchedulePeriod
+	First, Second Timeslot
+}
+
+// ScheduleReport summarises how well a period of the schedule is filled.
+type ScheduleReport struct {
+	// Period is the period of the schedule the report covers.
+	Period SchedulePeriod
+	// Gaps holds the unfilled periods that fall within broadcast hours.
+	Gaps []SchedulePeriod
+	// Sustainer holds the unfilled periods outside broadcast hours, during which the jukebox is on air.
+	Sustainer []SchedulePeriod
+	// Overlaps holds every period in which two timeslots are double-booked.
+	Overlaps []ScheduleOverlap
+}
+
+// AnalyseSchedule reports the gaps, sustainer periods and overlaps in timeslots between start and end,
+// given the station's daily broadcast hours.
+// The timeslots may come from GetTimeslotsForSeason, GetScheduleRange, or FlattenWeekSchedule on the result of
+// GetWeekSchedule, and need not be sorted.
+// This consumes no API requests.
+func AnalyseSchedule(timeslots []Timeslot, start, end time.Time, hours BroadcastHours) ScheduleReport {
+	report := ScheduleReport{Period: SchedulePeriod{Start: start, End: end}}
+
+	var sorted []Timeslot
+	for _, t := range timeslots {
+		if t.StartTime.Before(end) && start.Before(timeslotEnd(t)) {
+			sorted = append(sorted, t)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	for i, first := range sorted {
+		for _, second := range sorted[i+1:] {
+			if !second.StartTime.Before(timeslotEnd(first)) {
+				break
+			}
+			report.Overlaps = append(report.Overlaps, ScheduleOverlap{
+				SchedulePeriod: SchedulePeriod{
+					Start: latest(second.StartTime, start),
+					End:   earliest(timeslotEnd(first), timeslotEnd(second), end),
+				},
+				First:  first,
+				Second: second,
+			})
+		}
+	}
+
+	cursor := start
+	for _, t := range sorted {
+		if cursor.Before(t.StartTime) {
+			report.addUnfilled(SchedulePeriod{Start: cursor, End: t.StartTime}, hours)
+		}
+		cursor = latest(cursor, timeslotEnd(t))
+	}
+	if cursor.Before(end) {
+		report.addUnfilled(SchedulePeriod{Start: cursor, End: end}, hours)
+	}
+
+	return report
+}
+
+// addUnfilled splits the unfilled period p into gaps and sustainer periods, adding them to the report.
+func (r *ScheduleReport) addUnfilled(p SchedulePeriod, hours BroadcastHours) {
+	for t := p.Start; t.Before(p.End); {
+		on, next := hours.at(t)
+		seg := SchedulePeriod{Start: t, End: earliest(next, p.End)}
+		if on {
+			r.Gaps = appendPeriod(r.Gaps, seg)
+		} else {
+			r.Sustainer = appendPeriod(r.Sustainer, seg)
+		}
+		t = seg.End
+	}
+}
+
+// appendPeriod appends p to ps, merging it into the last period if the two are contiguous.
+func appendPeriod(ps []SchedulePeriod, p SchedulePeriod) []SchedulePeriod {
+	if n := len(ps); 0 < n && ps[n-1].End.Equal(p.Start) {
+		ps[n-1].End = p.End
+		return ps
+	}
+	return append(ps, p)
+}
+
+// timeslotEnd gets the time at which t finishes.
+func timeslotEnd(t Timeslot) time.Time {
+	return t.StartTime.Add(t.Duration)
+}
+
+// earliest gets the earliest of the given times.
+func earliest(t time.Time, ts ...time.Time) time.Time {
+	for _, u := range ts {
+		if u.Before(t) {
+			t = u
+		}
+	}
+	return t
+}
+
+// latest gets the latest of the given times.
+func latest(t time.Time, ts ...time.Time) time.Time {
+	for _, u := range ts {
+		if u.After(t) {
+			t = u
+		}
+	}
+	return t
+}
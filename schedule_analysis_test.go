@@ -0,0 +1,79 @@
+package myradio_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+// TestAnalyseSchedule tests whether AnalyseSchedule finds gaps, sustainer periods and overlaps in a day's schedule.
+func TestAnalyseSchedule(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2016, time.November, 14, hour, 0, 0, 0, time.UTC)
+	}
+	slot := func(id uint64, start, end int) myradio.Timeslot {
+		return myradio.Timeslot{TimeslotID: id, StartTime: at(start), Duration: time.Duration(end-start) * time.Hour}
+	}
+	period := func(start, end int) myradio.SchedulePeriod {
+		return myradio.SchedulePeriod{Start: at(start), End: at(end)}
+	}
+
+	timeslots := []myradio.Timeslot{slot(3, 14, 15), slot(1, 9, 11), slot(2, 10, 12)}
+	hours := myradio.BroadcastHours{Start: 8 * time.Hour, End: 22 * time.Hour}
+
+	expected := myradio.ScheduleReport{
+		Period:    period(0, 24),
+		Gaps:      []myradio.SchedulePeriod{period(8, 9), period(12, 14), period(15, 22)},
+		Sustainer: []myradio.SchedulePeriod{period(0, 8), period(22, 24)},
+		Overlaps: []myradio.ScheduleOverlap{
+			{SchedulePeriod: period(10, 11), First: timeslots[1], Second: timeslots[2]},
+		},
+	}
+
+	got := myradio.AnalyseSchedule(timeslots, at(0), at(24), hours)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected:\n%v\n\ngot:\n%v", expected, got)
+	}
+}
+
+// TestAnalyseScheduleOvernight tests whether AnalyseSchedule handles broadcast hours that run past midnight.
+func TestAnalyseScheduleOvernight(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2016, time.November, 14, hour, 0, 0, 0, time.UTC)
+	}
+	hours := myradio.BroadcastHours{Start: 20 * time.Hour, End: 2 * time.Hour}
+
+	got := myradio.AnalyseSchedule(nil, at(0), at(24), hours)
+	gaps := []myradio.SchedulePeriod{{Start: at(0), End: at(2)}, {Start: at(20), End: at(24)}}
+	if !reflect.DeepEqual(got.Gaps, gaps) {
+		t.Errorf("expected gaps:\n%v\n\ngot:\n%v", gaps, got.Gaps)
+	}
+	sustainer := []myradio.SchedulePeriod{{Start: at(2), End: at(20)}}
+	if !reflect.DeepEqual(got.Sustainer, sustainer) {
+		t.Errorf("expected sustainer:\n%v\n\ngot:\n%v", sustainer, got.Sustainer)
+	}
+}
+
+// TestAnalyseScheduleClockChange tests whether broadcast hours keep to the wall clock on the day the clocks go back.
+func TestAnalyseScheduleClockChange(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip("no time zone data:", err)
+	}
+	at := func(day, hour int) time.Time {
+		return time.Date(2016, time.October, day, hour, 0, 0, 0, london)
+	}
+	hours := myradio.BroadcastHours{Start: 8 * time.Hour, End: 22 * time.Hour}
+
+	got := myradio.AnalyseSchedule(nil, at(30, 0), at(31, 0), hours)
+	gaps := []myradio.SchedulePeriod{{Start: at(30, 8), End: at(30, 22)}}
+	if !reflect.DeepEqual(got.Gaps, gaps) {
+		t.Errorf("expected gaps:\n%v\n\ngot:\n%v", gaps, got.Gaps)
+	}
+	sustainer := []myradio.SchedulePeriod{{Start: at(30, 0), End: at(30, 8)}, {Start: at(30, 22), End: at(31, 0)}}
+	if !reflect.DeepEqual(got.Sustainer, sustainer) {
+		t.Errorf("expected sustainer:\n%v\n\ngot:\n%v", sustainer, got.Sustainer)
+	}
+}
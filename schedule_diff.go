@@ -0,0 +1,112 @@
+package myradio
+
+import (
+	"context"
+	"time"
+)
+
+// TimeslotChange pairs the old and new versions of a timeslot that changed between two schedules.
+type TimeslotChange struct {
+	Old, New Timeslot
+}
+
+// ScheduleDiff describes how one schedule differs from another.
+// Timeslots are matched up by TimeslotID.
+// A timeslot that was both retimed and retitled appears in both Retimed and Retitled.
+type ScheduleDiff struct {
+	// Added holds the timeslots only in the later schedule.
+	Added []Timeslot
+	// Removed holds the timeslots only in the earlier schedule.
+	Removed []Timeslot
+	// Retimed holds the timeslots whose start time or duration changed.
+	Retimed []TimeslotChange
+	// Retitled holds the timeslots whose title changed.
+	Retitled []TimeslotChange
+}
+
+// IsEmpty checks whether the diff records no changes at all.
+func (d ScheduleDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Retimed) == 0 && len(d.Retitled) == 0
+}
+
+// DiffSchedules compares two lists of timeslots, such as two saved snapshots of the schedule.
+// Added and changed timeslots appear in the order of after; removed ones in the order of before.
+// This consumes no API requests.
+func DiffSchedules(before, after []Timeslot) (diff ScheduleDiff) {
+	olds := make(map[uint64]Timeslot, len(before))
+	for _, t := range before {
+		olds[t.TimeslotID] = t
+	}
+	news := make(map[uint64]bool, len(after))
+
+	for _, n := range after {
+		news[n.TimeslotID] = true
+
+		o, ok := olds[n.TimeslotID]
+		if !ok {
+			diff.Added = append(diff.Added, n)
+			continue
+		}
+		if !o.StartTime.Equal(n.StartTime) || o.Duration != n.Duration {
+			diff.Retimed = append(diff.Retimed, TimeslotChange{Old: o, New: n})
+		}
+		if o.Title != n.Title {
+			diff.Retitled = append(diff.Retitled, TimeslotChange{Old: o, New: n})
+		}
+	}
+
+	for _, o := range before {
+		if !news[o.TimeslotID] {
+			diff.Removed = append(diff.Removed, o)
+		}
+	}
+
+	return
+}
+
+// DiffWeekSchedules compares two schedules from GetWeekSchedule.
+// This consumes no API requests.
+func DiffWeekSchedules(before, after map[int][]Timeslot) ScheduleDiff {
+	return DiffSchedules(FlattenWeekSchedule(before), FlattenWeekSchedule(after))
+}
+
+// ScheduleWatcher polls one week of the schedule, reporting each change to it.
+type ScheduleWatcher struct {
+	session    *Session
+	year, week int
+	interval   time.Duration
+}
+
+// NewScheduleWatcher constructs a ScheduleWatcher for ISO 8601 week week of year year,
+// which polls the schedule every interval.
+// An interval of zero or less means DefaultPollInterval.
+func (s *Session) NewScheduleWatcher(year, week int, interval time.Duration) *ScheduleWatcher {
+	return &ScheduleWatcher{session: s, year: year, week: week, interval: pollInterval(interval)}
+}
+
+// Run polls the schedule until ctx is done, sending a ScheduleDiff on out whenever the schedule changes.
+// The first poll sets the schedule to compare against, and sends nothing.
+// Run returns ctx's error once ctx is done, or the first error it gets from the API.
+// Each poll consumes one API request.
+func (w *ScheduleWatcher) Run(ctx context.Context, out chan<- ScheduleDiff) error {
+	var last map[int][]Timeslot
+	return poll(ctx, func() (time.Duration, error) {
+		schedule, err := w.session.GetWeekSchedule(w.year, w.week)
+		if err != nil {
+			return 0, err
+		}
+
+		if last != nil {
+			if diff := DiffWeekSchedules(last, schedule); !diff.IsEmpty() {
+				select {
+				case out <- diff:
+				case <-ctx.Done():
+					return 0, ctx.Err()
+				}
+			}
+		}
+		last = schedule
+
+		return w.interval, nil
+	})
+}
@@ -0,0 +1,41 @@
+package myradio_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+// TestDiffSchedules tests whether DiffSchedules picks up added, removed, retimed and retitled timeslots.
+func TestDiffSchedules(t *testing.T) {
+	slot := func(id uint64, title string, hour int) myradio.Timeslot {
+		ts := myradio.Timeslot{
+			TimeslotID: id,
+			StartTime:  time.Date(2016, time.November, 14, hour, 0, 0, 0, time.UTC),
+			Duration:   time.Hour,
+		}
+		ts.Title = title
+		return ts
+	}
+
+	before := []myradio.Timeslot{slot(1, "Breakfast", 8), slot(2, "Lunch", 12), slot(3, "Drive", 17)}
+	after := []myradio.Timeslot{slot(1, "Breakfast", 8), slot(3, "Drivetime", 18), slot(4, "Late", 23)}
+
+	expected := myradio.ScheduleDiff{
+		Added:    []myradio.Timeslot{after[2]},
+		Removed:  []myradio.Timeslot{before[1]},
+		Retimed:  []myradio.TimeslotChange{{Old: before[2], New: after[1]}},
+		Retitled: []myradio.TimeslotChange{{Old: before[2], New: after[1]}},
+	}
+
+	got := myradio.DiffSchedules(before, after)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected:\n%v\n\ngot:\n%v", expected, got)
+	}
+
+	if diff := myradio.DiffSchedules(before, before); !diff.IsEmpty() {
+		t.Error("expected no changes, got:", diff)
+	}
+}
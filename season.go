@@ -1,24 +1,38 @@
 package myradio
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Season represents a season in the MyRadio schedule.
 // A MyRadio season contains timeslots.
+// Submitted and FirstTime are derived from their raw fields whenever a Season is decoded,
+// and are not themselves encoded.
 type Season struct {
 	ShowMeta
-	SeasonID      int    `json:"season_id"`
-	SeasonNum     int    `json:"season_num"`
-	SubmittedRaw  string `json:"submitted"`
-	Submitted     time.Time
-	RequestedTime string `json:"requested_time"`
-	FirstTimeRaw  string `json:"first_time"`
-	FirstTime     time.Time
+	SeasonID      int               `json:"season_id"`
+	SeasonNum     int               `json:"season_num"`
+	SubmittedRaw  string            `json:"submitted"`
+	Submitted     time.Time         `json:"-"`
+	RequestedTime string            `json:"requested_time"`
+	FirstTimeRaw  string            `json:"first_time"`
+	FirstTime     time.Time         `json:"-"`
 	NumEpisodes   Link              `json:"num_episodes"`
 	AllocateLink  Link              `json:"allocatelink"`
 	RejectLink    Link              `json:"rejectlink"`
 	Subtype       ShowSeasonSubtype `json:"subtype"`
 }
 
+// UnmarshalJSON decodes a Season, then populates its times from their raw values.
+func (s *Season) UnmarshalJSON(b []byte) (err error) {
+	type season Season
+	if err = json.Unmarshal(b, (*season)(s)); err != nil {
+		return
+	}
+	return s.populateSeasonTimes()
+}
+
 // isScheduled returns whether the Season has been scheduled.
 // This consumes no API requests.
 func (s *Season) isScheduled() bool {
@@ -41,45 +55,20 @@ func (s *Season) populateSeasonTimes() (err error) {
 // GetSeason retrieves the season with the given ID.
 // This consumes one API request.
 func (s *Session) GetSeason(id int) (season Season, err error) {
-	if err = s.getf("/season/%d/", id).Into(&season); err != nil {
-		return
-	}
-
-	err = season.populateSeasonTimes()
-
+	err = s.getf("/season/%d/", id).Into(&season)
 	return
 }
 
 // GetTimeslotsForSeason retrieves all timeslots for the season with the given ID.
 // This consumes one API request.
 func (s *Session) GetTimeslotsForSeason(id int) (timeslots []Timeslot, err error) {
-	if err = s.getf("/season/%d/alltimeslots/", id).Into(&timeslots); err != nil {
-		return
-	}
-
-	for k := range timeslots {
-		err = timeslots[k].populateTimeslotTimes()
-		if err != nil {
-			return
-		}
-	}
-
+	err = s.getf("/season/%d/alltimeslots/", id).Into(&timeslots)
 	return
 }
 
 // GetAllSeasonsInLatestTerm gets all seasons in the most recent term.
 // This consumes one API request.
 func (s *Session) GetAllSeasonsInLatestTerm() (seasons []Season, err error) {
-	if err = s.get("/season/allseasonsinlatestterm/").Into(&seasons); err != nil {
-		return
-	}
-
-	for k := range seasons {
-		err = seasons[k].populateSeasonTimes()
-		if err != nil {
-			return
-		}
-	}
-
+	err = s.get("/season/allseasonsinlatestterm/").Into(&seasons)
 	return
 }
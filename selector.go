@@ -1,5 +1,17 @@
 package myradio
 
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/UniversityRadioYork/myradio-go/api"
+)
+
+// Deprecated: these values collide (SelectorJukebox and SelectorHub are both 3), as the studio and selected-from
+// fields number their values differently.
+// Use the typed Studio and SelectedFrom constants instead.
 const (
 	// Values for the current selection/where it was selected from
 	SelectorStudio1 = 1
@@ -9,23 +21,182 @@ const (
 	SelectorOB      = 4
 	SelectorAux     = 0
 	SelectorHub     = 3
-	// Values for the selector lock
-	LockOff = 0
-	LockAux = 1
-	LockKey = 2
-	// Values for studio power
-	OnNone = 0
-	OnS1   = 1
-	OnS2   = 2
-	OnBoth = 3
 )
 
+// Studio identifies a source the selector can put on air.
+type Studio int
+
+const (
+	// Studio1 is Studio 1.
+	Studio1 Studio = 1
+	// Studio2 is Studio 2.
+	Studio2 Studio = 2
+	// StudioJukebox is the jukebox.
+	StudioJukebox Studio = 3
+	// StudioOB is the outside broadcast line.
+	StudioOB Studio = 4
+	// StudioOffAir means nothing is on air.
+	StudioOffAir Studio = 8
+)
+
+// String gets a human-readable name for the studio.
+func (s Studio) String() string {
+	switch s {
+	case Studio1:
+		return "Studio 1"
+	case Studio2:
+		return "Studio 2"
+	case StudioJukebox:
+		return "Jukebox"
+	case StudioOB:
+		return "Outside Broadcast"
+	case StudioOffAir:
+		return "Off Air"
+	default:
+		return fmt.Sprintf("Studio(%d)", int(s))
+	}
+}
+
+// IsValid checks whether s is one of the known studios.
+func (s Studio) IsValid() bool {
+	switch s {
+	case Studio1, Studio2, StudioJukebox, StudioOB, StudioOffAir:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsLive checks whether the studio is a live source, rather than the jukebox or off air.
+func (s Studio) IsLive() bool {
+	return s == Studio1 || s == Studio2 || s == StudioOB
+}
+
+// SelectedFrom identifies where the selector was last switched from.
+type SelectedFrom int
+
+const (
+	// SelectedFromAux means the selector was switched from the auxiliary input.
+	SelectedFromAux SelectedFrom = 0
+	// SelectedFromStudio1 means the selector was switched from Studio 1.
+	SelectedFromStudio1 SelectedFrom = 1
+	// SelectedFromStudio2 means the selector was switched from Studio 2.
+	SelectedFromStudio2 SelectedFrom = 2
+	// SelectedFromHub means the selector was switched from the hub, for example through MyRadio.
+	SelectedFromHub SelectedFrom = 3
+)
+
+// String gets a human-readable name for where the selector was switched from.
+func (f SelectedFrom) String() string {
+	switch f {
+	case SelectedFromAux:
+		return "Aux"
+	case SelectedFromStudio1:
+		return "Studio 1"
+	case SelectedFromStudio2:
+		return "Studio 2"
+	case SelectedFromHub:
+		return "Hub"
+	default:
+		return fmt.Sprintf("SelectedFrom(%d)", int(f))
+	}
+}
+
+// IsValid checks whether f is one of the known places the selector can be switched from.
+func (f SelectedFrom) IsValid() bool {
+	return SelectedFromAux <= f && f <= SelectedFromHub
+}
+
+// SelectorLock is the state of the selector lock.
+type SelectorLock int
+
+const (
+	// LockOff means the selector is unlocked.
+	LockOff SelectorLock = 0
+	// LockAux means the selector is locked by the auxiliary input.
+	LockAux SelectorLock = 1
+	// LockKey means the selector is locked by the key switch.
+	LockKey SelectorLock = 2
+)
+
+// String gets a human-readable name for the lock state.
+func (l SelectorLock) String() string {
+	switch l {
+	case LockOff:
+		return "Unlocked"
+	case LockAux:
+		return "Aux Lock"
+	case LockKey:
+		return "Key Lock"
+	default:
+		return fmt.Sprintf("SelectorLock(%d)", int(l))
+	}
+}
+
+// IsValid checks whether l is one of the known lock states.
+func (l SelectorLock) IsValid() bool {
+	return LockOff <= l && l <= LockKey
+}
+
+// StudioPower says which studios are powered on.
+type StudioPower int
+
+const (
+	// OnNone means neither studio is powered.
+	OnNone StudioPower = 0
+	// OnS1 means only Studio 1 is powered.
+	OnS1 StudioPower = 1
+	// OnS2 means only Studio 2 is powered.
+	OnS2 StudioPower = 2
+	// OnBoth means both studios are powered.
+	OnBoth StudioPower = 3
+)
+
+// String gets a human-readable name for the power state.
+func (p StudioPower) String() string {
+	switch p {
+	case OnNone:
+		return "None"
+	case OnS1:
+		return "Studio 1"
+	case OnS2:
+		return "Studio 2"
+	case OnBoth:
+		return "Both"
+	default:
+		return fmt.Sprintf("StudioPower(%d)", int(p))
+	}
+}
+
+// IsPowered checks whether the given studio is powered.
+// Only Studio1 and Studio2 have power states; every other studio counts as unpowered.
+func (p StudioPower) IsPowered(s Studio) bool {
+	switch s {
+	case Studio1:
+		return p == OnS1 || p == OnBoth
+	case Studio2:
+		return p == OnS2 || p == OnBoth
+	default:
+		return false
+	}
+}
+
 // SelectorInfo holds data from the /selector/query endpoint
 type SelectorInfo struct {
-	Studio       int `json:"studio"`
-	Lock         int `json:"lock"`
-	SelectedFrom int `json:"selectedfrom"`
-	Power        int `json:"power"`
+	Studio       Studio       `json:"studio"`
+	Lock         SelectorLock `json:"lock"`
+	SelectedFrom SelectedFrom `json:"selectedfrom"`
+	Power        StudioPower  `json:"power"`
+}
+
+// IsOnAir checks whether the selector is putting anything on air.
+func (i *SelectorInfo) IsOnAir() bool {
+	return i.Studio != StudioOffAir
+}
+
+// ActiveStudio gets the studio on air, and whether it is a live studio rather than the jukebox or off air.
+func (i *SelectorInfo) ActiveStudio() (Studio, bool) {
+	return i.Studio, i.Studio.IsLive()
 }
 
 // GetSelectorInfo retrieves the current status of the selector
@@ -34,3 +205,114 @@ func (s *Session) GetSelectorInfo() (info *SelectorInfo, err error) {
 	err = s.get("/selector/query").Into(&info)
 	return
 }
+
+// SelectStudio asks the selector to switch to the given studio.
+// The switch may still be refused if the selector is locked.
+// This consumes one API request.
+func (s *Session) SelectStudio(studio Studio) error {
+	if !studio.IsValid() {
+		return fmt.Errorf("SelectStudio: %d is not a known studio", int(studio))
+	}
+
+	_, err := s.post("/selector/selectstudio", map[string][]string{
+		"studio": {strconv.Itoa(int(studio))},
+	}).JSON()
+	return err
+}
+
+// SetSelectorLock locks the selector with the given lock, stopping it from being switched until released.
+// To release the lock, use ReleaseSelectorLock rather than passing LockOff.
+// This consumes one API request.
+func (s *Session) SetSelectorLock(lock SelectorLock) error {
+	if !lock.IsValid() || lock == LockOff {
+		return fmt.Errorf("SetSelectorLock: %d is not a lock that can be set", int(lock))
+	}
+
+	_, err := s.post("/selector/lock", map[string][]string{
+		"lock": {strconv.Itoa(int(lock))},
+	}).JSON()
+	return err
+}
+
+// ReleaseSelectorLock releases any lock on the selector.
+// This consumes one API request.
+func (s *Session) ReleaseSelectorLock() error {
+	_, err := s.post("/selector/unlock", map[string][]string{}).JSON()
+	return err
+}
+
+// SelectorChange records one switch of the selector.
+type SelectorChange struct {
+	Time         Time         `json:"time"`
+	Studio       Studio       `json:"studio"`
+	SelectedFrom SelectedFrom `json:"selectedfrom"`
+}
+
+// GetSelectorHistory retrieves every switch of the selector between start and end, oldest first.
+// This consumes one API request.
+func (s *Session) GetSelectorHistory(start, end time.Time) (changes []SelectorChange, err error) {
+	rq := api.NewRequest("/selector/history")
+	rq.Params["start"] = []string{strconv.FormatInt(start.Unix(), 10)}
+	rq.Params["end"] = []string{strconv.FormatInt(end.Unix(), 10)}
+	err = s.do(rq).Into(&changes)
+	return
+}
+
+// SelectorEvent reports a change in the state of the selector.
+type SelectorEvent struct {
+	// Previous is the selector state before the change.
+	// It is nil for the event sent on the first poll.
+	Previous *SelectorInfo
+	// Now is the selector state after the change.
+	Now SelectorInfo
+	// SourceChanged is true if the selected studio changed.
+	SourceChanged bool
+	// Time is the time at which the change was seen.
+	Time time.Time
+}
+
+// SelectorWatcher polls the selector, reporting each change to it.
+type SelectorWatcher struct {
+	session  *Session
+	interval time.Duration
+}
+
+// NewSelectorWatcher constructs a SelectorWatcher that polls every interval.
+// An interval of zero or less means DefaultPollInterval.
+func (s *Session) NewSelectorWatcher(interval time.Duration) *SelectorWatcher {
+	return &SelectorWatcher{session: s, interval: pollInterval(interval)}
+}
+
+// Run polls the selector until ctx is done, sending an event on out whenever its state changes.
+// The first poll always sends an event, so that consumers can start from a known state.
+// Run returns ctx's error once ctx is done, or the first error it gets from the API.
+// Each poll consumes one API request.
+func (w *SelectorWatcher) Run(ctx context.Context, out chan<- SelectorEvent) error {
+	var last *SelectorInfo
+	return poll(ctx, func() (time.Duration, error) {
+		info, err := w.session.GetSelectorInfo()
+		if err != nil {
+			return 0, err
+		}
+		if info == nil {
+			info = &SelectorInfo{}
+		}
+
+		if last == nil || *last != *info {
+			ev := SelectorEvent{
+				Previous:      last,
+				Now:           *info,
+				SourceChanged: last == nil || last.Studio != info.Studio,
+				Time:          time.Now(),
+			}
+			select {
+			case out <- ev:
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+		last = info
+
+		return w.interval, nil
+	})
+}
@@ -0,0 +1,110 @@
+package myradio_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+// TestGetSelectorInfo tests the unmarshalling and helpers of GetSelectorInfo.
+// It does not test the API endpoint.
+func TestGetSelectorInfo(t *testing.T) {
+	expected := &myradio.SelectorInfo{
+		Studio:       myradio.Studio2,
+		Lock:         myradio.LockKey,
+		SelectedFrom: myradio.SelectedFromHub,
+		Power:        myradio.OnBoth,
+	}
+
+	session, err := myradio.MockSession([]byte(`{"studio": 2, "lock": 2, "selectedfrom": 3, "power": 3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := session.GetSelectorInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(info, expected) {
+		t.Errorf("expected:\n%v\n\ngot:\n%v", expected, info)
+	}
+
+	if !info.IsOnAir() {
+		t.Error("selector on Studio 2 should be on air")
+	}
+	if studio, live := info.ActiveStudio(); studio != myradio.Studio2 || !live {
+		t.Error("expected active studio Studio 2, got:", studio, live)
+	}
+	if !info.Power.IsPowered(myradio.Studio1) {
+		t.Error("both studios should be powered")
+	}
+	if got := info.SelectedFrom.String(); got != "Hub" {
+		t.Error("expected: Hub got:", got)
+	}
+}
+
+// TestSelectorWriteValidation tests whether SelectStudio and SetSelectorLock reject values they can't send.
+// It does not test the API endpoint.
+func TestSelectorWriteValidation(t *testing.T) {
+	session, err := myradio.MockSession([]byte(`true`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	studios := []struct {
+		studio myradio.Studio
+		ok     bool
+	}{
+		{myradio.Studio1, true},
+		{myradio.StudioJukebox, true},
+		{myradio.StudioOffAir, true},
+		{myradio.Studio(0), false},
+		{myradio.Studio(5), false},
+	}
+	for _, c := range studios {
+		if err := session.SelectStudio(c.studio); (err == nil) != c.ok {
+			t.Errorf("SelectStudio(%v): expected ok=%v, got error: %v", c.studio, c.ok, err)
+		}
+	}
+
+	locks := []struct {
+		lock myradio.SelectorLock
+		ok   bool
+	}{
+		{myradio.LockAux, true},
+		{myradio.LockKey, true},
+		{myradio.LockOff, false},
+		{myradio.SelectorLock(3), false},
+	}
+	for _, c := range locks {
+		if err := session.SetSelectorLock(c.lock); (err == nil) != c.ok {
+			t.Errorf("SetSelectorLock(%v): expected ok=%v, got error: %v", c.lock, c.ok, err)
+		}
+	}
+}
+
+// TestGetSelectorHistory tests the unmarshalling of GetSelectorHistory.
+// It does not test the API endpoint.
+func TestGetSelectorHistory(t *testing.T) {
+	session, err := myradio.MockSession([]byte(`[
+		{"time": 1479081600, "studio": 1, "selectedfrom": 1},
+		{"time": 1479085200, "studio": 3, "selectedfrom": 3}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []myradio.SelectorChange{
+		{Time: myradio.Time{Time: time.Unix(1479081600, 0)}, Studio: myradio.Studio1, SelectedFrom: myradio.SelectedFromStudio1},
+		{Time: myradio.Time{Time: time.Unix(1479085200, 0)}, Studio: myradio.StudioJukebox, SelectedFrom: myradio.SelectedFromHub},
+	}
+	changes, err := session.GetSelectorHistory(time.Unix(1479081600, 0), time.Unix(1479088800, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected:\n%v\n\ngot:\n%v", expected, changes)
+	}
+}
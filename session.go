@@ -3,6 +3,8 @@ package myradio
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/url"
 
 	"github.com/UniversityRadioYork/myradio-go/api"
@@ -46,6 +48,16 @@ func (s *Session) do(r *api.Request) *api.Response {
 	return s.requester.Do(r)
 }
 
+// stream fulfils a request for raw content, copying it to w.
+// It fails if the session's requester can't handle raw content.
+func (s *Session) stream(r *api.Request, w io.Writer) (int64, error) {
+	st, ok := s.requester.(api.Streamer)
+	if !ok {
+		return 0, errors.New("session can't stream raw content")
+	}
+	return st.Stream(r, w)
+}
+
 // get creates, and fulfils, a GET request for the given endpoint.
 func (s *Session) get(endpoint string) *api.Response {
 	return s.do(api.NewRequest(endpoint))
@@ -73,6 +85,14 @@ func (s *Session) putf(format string, body bytes.Buffer, params ...interface{})
 	return s.do(r)
 }
 
+// deletef creates, and fulfils, a DELETE request for the endpoint created by
+// the given format string and parameters.
+func (s *Session) deletef(format string, params ...interface{}) *api.Response {
+	r := api.NewRequestf(format, params...)
+	r.ReqType = api.DeleteReq
+	return s.do(r)
+}
+
 // post creates, and fulfils, a POST request for the given endpoint,
 // using the given form parameters
 func (s *Session) post(endpoint string, formParams map[string][]string) *api.Response {
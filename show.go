@@ -54,17 +54,7 @@ func (s *Session) GetShow(id int) (show *ShowMeta, err error) {
 // GetSeasons retrieves the seasons of the show with the given ID.
 // This consumes one API request.
 func (s *Session) GetSeasons(id int) (seasons []Season, err error) {
-	if err = s.getf("/show/%d/allseasons", id).Into(&seasons); err != nil {
-		return
-	}
-
-	for i := range seasons {
-		err = seasons[i].populateSeasonTimes()
-		if err != nil {
-			return
-		}
-	}
-
+	err = s.getf("/show/%d/allseasons", id).Into(&seasons)
 	return
 }
 
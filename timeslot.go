@@ -2,7 +2,10 @@ package myradio
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -14,21 +17,60 @@ type Time struct {
 	time.Time
 }
 
-// UnmarshalJSON a method to convert myradio times to unit time stamps
-func (t *Time) UnmarshalJSON(b []byte) (err error) {
+// endOfTime is the sentinel MyRadio uses in place of a time that never comes.
+const endOfTime = "The End of Time"
+
+// UnmarshalJSON converts a MyRadio time into a Time.
+// MyRadio sends times as Unix timestamps, either bare or quoted; as RFC 3339 or 'DD/MM/YYYY HH:MM' strings;
+// or as "The End of Time", which becomes the zero Time.
+// A null leaves the Time untouched.
+func (t *Time) UnmarshalJSON(b []byte) error {
 	var str = string(b)
+	if str == "null" {
+		return nil
+	}
+
+	if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+		*t = Time{time.Unix(i, 0)}
+		return nil
+	}
 
-	if str == "\"The End of Time\"" {
+	if err := json.Unmarshal(b, &str); err != nil {
+		return fmt.Errorf("Time.UnmarshalJSON: can't interpret %s as a time", b)
+	}
+	return t.parse(str)
+}
+
+// parse sets t from a string-encoded MyRadio time.
+func (t *Time) parse(str string) error {
+	if str == endOfTime {
 		*t = Time{}
-	} else {
-		i, err := strconv.ParseInt(str, 10, 64)
-		if err != nil {
-			return err
-		}
+		return nil
+	}
+
+	if i, err := strconv.ParseInt(str, 10, 64); err == nil {
 		*t = Time{time.Unix(i, 0)}
+		return nil
+	}
+	if tm, err := time.Parse(time.RFC3339, str); err == nil {
+		*t = Time{tm}
+		return nil
+	}
+	if tm, err := parseShortTime(str); err == nil {
+		*t = Time{tm}
+		return nil
 	}
 
-	return
+	return fmt.Errorf("Time.UnmarshalJSON: can't interpret %q as a time", str)
+}
+
+// MarshalJSON converts a Time back into MyRadio's Unix timestamp format.
+// The zero Time becomes "The End of Time", so that it survives a round trip.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(strconv.Quote(endOfTime)), nil
+	}
+	return []byte(strconv.FormatInt(t.Unix(), 10)), nil
 }
 
 // CurrentAndNext stores a pair of current and next show.
@@ -58,26 +100,58 @@ func (s *Show) Ends() bool {
 
 // Timeslot contains information about a single timeslot in the URY schedule.
 // A timeslot is a single slice of time on the schedule, typically one hour long.
+// StartTime and Duration are derived from their raw fields whenever a Timeslot is decoded,
+// and are not themselves encoded.
 type Timeslot struct {
 	Season
+	TimeslotID     uint64        `json:"timeslot_id"`
+	TimeslotNum    int           `json:"timeslot_num"`
+	Tags           []string      `json:"tags"`
+	Time           Time          `json:"time"`
+	StartTime      time.Time     `json:"-"`
+	StartTimeRaw   string        `json:"start_time"`
+	Duration       time.Duration `json:"-"`
+	DurationRaw    string        `json:"duration"`
+	MixcloudStatus string        `json:"mixcloud_status"`
+}
+
+// timeslotFields holds the fields Timeslot has on top of its Season.
+// We can't decode a Timeslot through a plain alias type, as Season's UnmarshalJSON would be promoted onto it and
+// swallow the whole object, so these must be kept in step with Timeslot.
+type timeslotFields struct {
 	TimeslotID     uint64   `json:"timeslot_id"`
 	TimeslotNum    int      `json:"timeslot_num"`
 	Tags           []string `json:"tags"`
 	Time           Time     `json:"time"`
-	StartTime      time.Time
-	StartTimeRaw   string `json:"start_time"`
-	Duration       time.Duration
-	DurationRaw    string `json:"duration"`
-	MixcloudStatus string `json:"mixcloud_status"`
+	StartTimeRaw   string   `json:"start_time"`
+	DurationRaw    string   `json:"duration"`
+	MixcloudStatus string   `json:"mixcloud_status"`
 }
 
-// populateTimeslotTimes sets the times for the given Timeslot given their raw values.
-func (t *Timeslot) populateTimeslotTimes() (err error) {
-	// Remember: a Timeslot is a supertype of Season.
-	if err = t.populateSeasonTimes(); err != nil {
+// UnmarshalJSON decodes a Timeslot, then populates its times from their raw values.
+func (t *Timeslot) UnmarshalJSON(b []byte) (err error) {
+	if err = json.Unmarshal(b, &t.Season); err != nil {
+		return
+	}
+
+	var f timeslotFields
+	if err = json.Unmarshal(b, &f); err != nil {
 		return
 	}
+	t.TimeslotID = f.TimeslotID
+	t.TimeslotNum = f.TimeslotNum
+	t.Tags = f.Tags
+	t.Time = f.Time
+	t.StartTimeRaw = f.StartTimeRaw
+	t.DurationRaw = f.DurationRaw
+	t.MixcloudStatus = f.MixcloudStatus
 
+	return t.populateTimeslotTimes()
+}
+
+// populateTimeslotTimes sets the times for the given Timeslot given their raw values.
+// The Season's own times are populated when it is decoded.
+func (t *Timeslot) populateTimeslotTimes() (err error) {
 	t.StartTime, err = parseShortTime(t.StartTimeRaw)
 	if err != nil {
 		return
@@ -88,24 +162,66 @@ func (t *Timeslot) populateTimeslotTimes() (err error) {
 }
 
 // TracklistItem represents a single item in a show tracklist.
+// Time and StartTime are derived from their raw fields whenever a TracklistItem is decoded,
+// and are not themselves encoded.
 type TracklistItem struct {
 	Track
-	Album        Album `json:"album"`
-	EditLink     Link  `json:"editlink"`
-	DeleteLink   Link  `json:"deletelink"`
-	Time         time.Time
-	TimeRaw      int64 `json:"time"`
-	StartTime    time.Time
+	Album        Album     `json:"album"`
+	EditLink     Link      `json:"editlink"`
+	DeleteLink   Link      `json:"deletelink"`
+	Time         time.Time `json:"-"`
+	TimeRaw      int64     `json:"time"`
+	StartTime    time.Time `json:"-"`
+	StartTimeRaw string    `json:"starttime"`
+	AudioLogID   uint      `json:"audiologid"`
+}
+
+// tracklistItemFields holds the fields TracklistItem has on top of its Track.
+// As with timeslotFields, Track's UnmarshalJSON stops us decoding through an alias type,
+// so these must be kept in step with TracklistItem.
+type tracklistItemFields struct {
+	Album        Album  `json:"album"`
+	EditLink     Link   `json:"editlink"`
+	DeleteLink   Link   `json:"deletelink"`
+	TimeRaw      int64  `json:"time"`
 	StartTimeRaw string `json:"starttime"`
 	AudioLogID   uint   `json:"audiologid"`
 }
 
+// UnmarshalJSON decodes a TracklistItem, then populates its times from their raw values.
+// The item's album goes only in its Album field; the embedded Track's Album is left nil.
+func (t *TracklistItem) UnmarshalJSON(b []byte) (err error) {
+	if err = json.Unmarshal(b, &t.Track); err != nil {
+		return
+	}
+	t.Track.Album = nil
+
+	var f tracklistItemFields
+	if err = json.Unmarshal(b, &f); err != nil {
+		return
+	}
+	t.Album = f.Album
+	t.EditLink = f.EditLink
+	t.DeleteLink = f.DeleteLink
+	t.TimeRaw = f.TimeRaw
+	t.StartTimeRaw = f.StartTimeRaw
+	t.AudioLogID = f.AudioLogID
+
+	t.Time = time.Unix(t.TimeRaw, 0)
+	t.StartTime, err = time.Parse("02/01/2006 15:04:05", t.StartTimeRaw)
+	return
+}
+
 // GetCurrentAndNext gets the current and next shows at the time of the call.
+// If MyRadio sends back nothing, both shows are empty.
 // This consumes one API request.
 func (s *Session) GetCurrentAndNext() (can *CurrentAndNext, err error) {
 	if err = s.get("/timeslot/currentandnext").Into(&can); err != nil {
 		return
 	}
+	if can == nil {
+		return &CurrentAndNext{}, nil
+	}
 
 	// Sometimes, we only get a Current, not a Next.
 	// Don't try populate times on a show that doesn't exist.
@@ -122,15 +238,7 @@ func (s *Session) GetPreviousTimeslots(numOfTimeslots int) (timeslots []Timeslot
 	rq.Params["n"] = []string{strconv.Itoa(numOfTimeslots)}
 	rs := s.do(rq)
 
-	if err = rs.Into(&timeslots); err != nil {
-		return
-	}
-	for k := range timeslots {
-		err = timeslots[k].populateTimeslotTimes()
-		if err != nil {
-			return
-		}
-	}
+	err = rs.Into(&timeslots)
 	return
 }
 
@@ -189,34 +297,76 @@ func destringTimeslots(stringyTimeslots map[string][]Timeslot) (map[int][]Timesl
 		if derr != nil {
 			return nil, derr
 		}
-		for i := range ts {
-			if terr := ts[i].populateTimeslotTimes(); terr != nil {
-				return nil, terr
+		timeslots[day] = ts
+	}
+
+	return timeslots, nil
+}
+
+// FlattenWeekSchedule converts a schedule from GetWeekSchedule into a single slice of timeslots.
+// The slice progresses chronologically from the start of Monday to the end of Sunday.
+// This consumes no API requests.
+func FlattenWeekSchedule(schedule map[int][]Timeslot) []Timeslot {
+	var timeslots []Timeslot
+	for day := 1; day <= 7; day++ {
+		timeslots = append(timeslots, schedule[day]...)
+	}
+	return timeslots
+}
+
+// GetScheduleRange gets all timeslots that overlap the period from start (inclusive) to end (exclusive).
+// It returns the timeslots as a single slice, sorted by start time.
+// This consumes one API request for each ISO 8601 week that begins before end, counting from the week containing
+// the day before start. When start is a Monday, that includes the previous week, which may hold timeslots that run
+// into the period.
+func (s *Session) GetScheduleRange(start, end time.Time) ([]Timeslot, error) {
+	if end.Before(start) {
+		return nil, errors.New("GetScheduleRange: end is before start")
+	}
+
+	seen := make(map[uint64]bool)
+	var timeslots []Timeslot
+	for monday := startOfISOWeek(start.AddDate(0, 0, -1)); monday.Before(end); monday = monday.AddDate(0, 0, 7) {
+		// Taking the ISO year and week from the Monday keeps us right across year boundaries and week 53.
+		year, week := monday.ISOWeek()
+		schedule, err := s.GetWeekSchedule(year, week)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range FlattenWeekSchedule(schedule) {
+			if seen[t.TimeslotID] || !t.StartTime.Before(end) || !start.Before(timeslotEnd(t)) {
+				continue
 			}
+			seen[t.TimeslotID] = true
+			timeslots = append(timeslots, t)
 		}
-		timeslots[day] = ts
 	}
 
+	sort.SliceStable(timeslots, func(i, j int) bool {
+		return timeslots[i].StartTime.Before(timeslots[j].StartTime)
+	})
 	return timeslots, nil
 }
 
+// startOfISOWeek gets midnight on the Monday of the ISO 8601 week containing t, in t's location.
+func startOfISOWeek(t time.Time) time.Time {
+	// Go counts weekdays from Sunday = 0; ISO 8601 counts from Monday.
+	sinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-sinceMonday, 0, 0, 0, 0, t.Location())
+}
+
 // GetTimeslot retrieves the timeslot with the given ID.
 // This consumes one API request.
 func (s *Session) GetTimeslot(id int) (timeslot Timeslot, err error) {
-	if err = s.getf("/timeslot/%d", id).Into(&timeslot); err != nil {
-		return
-	}
-	err = timeslot.populateTimeslotTimes()
+	err = s.getf("/timeslot/%d", id).Into(&timeslot)
 	return
 }
 
 // GetCurrentTimeslot retrieves the current timeslot.
 // This consumes one API request.
 func (s *Session) GetCurrentTimeslot() (timeslot Timeslot, err error) {
-	if err = s.get("/timeslot/currenttimeslot").Into(&timeslot); err != nil {
-		return
-	}
-	err = timeslot.populateTimeslotTimes()
+	err = s.get("/timeslot/currenttimeslot").Into(&timeslot)
 	return
 }
 
@@ -225,26 +375,14 @@ func (s *Session) GetCurrentTimeslot() (timeslot Timeslot, err error) {
 func (s *Session) GetCurrentTimeslotAtTime(time int) (timeslot Timeslot, err error) {
 	paramMap := make(map[string][]string)
 	paramMap["time"] = []string{strconv.Itoa(time)}
-	if err = s.getWithQueryParams("/timeslot/currenttimeslot", paramMap).Into(&timeslot); err != nil {
-		return
-	}
-	err = timeslot.populateTimeslotTimes()
+	err = s.getWithQueryParams("/timeslot/currenttimeslot", paramMap).Into(&timeslot)
 	return
 }
 
 // GetTrackListForTimeslot retrieves the tracklist for the timeslot with the given ID.
 // This consumes one API request.
 func (s *Session) GetTrackListForTimeslot(id int) (tracklist []TracklistItem, err error) {
-	if err = s.getf("/tracklistItem/tracklistfortimeslot/%d", id).Into(&tracklist); err != nil {
-		return
-	}
-	for k, v := range tracklist {
-		tracklist[k].Time = time.Unix(tracklist[k].TimeRaw, 0)
-		tracklist[k].StartTime, err = time.Parse("02/01/2006 15:04:05", v.StartTimeRaw)
-		if err != nil {
-			return nil, err
-		}
-	}
+	err = s.getf("/tracklistItem/tracklistfortimeslot/%d", id).Into(&tracklist)
 	return
 }
 
@@ -254,9 +392,5 @@ func (s *Session) PutMessage(id uint64, msg string) (err error) {
 	var timeslot Timeslot
 	msg = "message=" + msg
 	err = s.putf("/timeslot/%d/sendmessage", *bytes.NewBufferString(msg), id).Into(&timeslot)
-	if err != nil {
-		return
-	}
-	err = timeslot.populateTimeslotTimes()
 	return
 }
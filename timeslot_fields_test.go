@@ -0,0 +1,41 @@
+package myradio
+
+import (
+	"reflect"
+	"testing"
+)
+
+// decodedFields gets the fields of struct type t that its UnmarshalJSON decodes by hand:
+// everything but embedded structs and fields not themselves encoded.
+func decodedFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous || f.Tag.Get("json") == "-" {
+			continue
+		}
+		f.Index, f.Offset = nil, 0
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// TestDecodedFieldsInStep tests that the structs Timeslot and TracklistItem decode their own fields through
+// have the same names, types and tags as those fields.
+func TestDecodedFieldsInStep(t *testing.T) {
+	cases := []struct {
+		name       string
+		full, part reflect.Type
+	}{
+		{"Timeslot", reflect.TypeOf(Timeslot{}), reflect.TypeOf(timeslotFields{})},
+		{"TracklistItem", reflect.TypeOf(TracklistItem{}), reflect.TypeOf(tracklistItemFields{})},
+	}
+
+	for _, c := range cases {
+		expected := decodedFields(c.full)
+		got := decodedFields(c.part)
+		if !reflect.DeepEqual(expected, got) {
+			t.Errorf("%s: expected:\n%v\n\ngot:\n%v", c.name, expected, got)
+		}
+	}
+}
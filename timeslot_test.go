@@ -1,6 +1,7 @@
 package myradio_test
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 	"time"
@@ -65,3 +66,166 @@ func TestGetWeekScheduleZero(t *testing.T) {
 		}
 	}
 }
+
+// TestTimeRoundTrip tests whether Times survive being marshalled and unmarshalled.
+func TestTimeRoundTrip(t *testing.T) {
+	cases := []struct {
+		t myradio.Time
+		j string
+	}{
+		{t: myradio.Time{}, j: `"The End of Time"`},
+		{t: myradio.Time{time.Unix(1239621071, 0)}, j: `1239621071`},
+	}
+
+	for _, c := range cases {
+		b, err := json.Marshal(c.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != c.j {
+			t.Error("expected:", c.j, "got:", string(b))
+		}
+
+		var got myradio.Time
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(c.t.Time) {
+			t.Error("expected:", c.t, "got:", got)
+		}
+	}
+}
+
+const timeslotJSON = `{
+	"show_id": 1,
+	"title": "The Breakfast Show",
+	"season_id": 2,
+	"submitted": "01/09/2016 12:00",
+	"first_time": "Not Scheduled",
+	"timeslot_id": 3,
+	"timeslot_num": 4,
+	"time": 1479081600,
+	"start_time": "14/11/2016 08:00",
+	"duration": "01:30:00"
+}`
+
+// TestTimeslotRoundTrip tests whether Timeslots survive being marshalled and unmarshalled.
+func TestTimeslotRoundTrip(t *testing.T) {
+	var ts myradio.Timeslot
+	if err := json.Unmarshal([]byte(timeslotJSON), &ts); err != nil {
+		t.Fatal(err)
+	}
+
+	if ts.Title != "The Breakfast Show" || ts.SeasonID != 2 || ts.TimeslotID != 3 {
+		t.Error("timeslot decoded incorrectly:", ts)
+	}
+	if !ts.StartTime.Equal(time.Date(2016, time.November, 14, 8, 0, 0, 0, time.Local)) {
+		t.Error("start time decoded incorrectly:", ts.StartTime)
+	}
+	if ts.Duration != 90*time.Minute {
+		t.Error("duration decoded incorrectly:", ts.Duration)
+	}
+
+	b, err := json.Marshal(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got myradio.Timeslot
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, ts) {
+		t.Errorf("expected:\n%v\n\ngot:\n%v", ts, got)
+	}
+}
+
+// TestTimeUnmarshal tests whether Time accepts each of the forms MyRadio uses for times.
+func TestTimeUnmarshal(t *testing.T) {
+	cases := []struct {
+		j string
+		e time.Time
+	}{
+		{j: `1239621071`, e: time.Unix(1239621071, 0)},
+		{j: `"1239621071"`, e: time.Unix(1239621071, 0)},
+		{j: `"The End of Time"`, e: time.Time{}},
+		{j: `"2009-04-13T11:11:11Z"`, e: time.Date(2009, time.April, 13, 11, 11, 11, 0, time.UTC)},
+		{j: `"13/04/2009 11:11"`, e: time.Date(2009, time.April, 13, 11, 11, 0, 0, time.Local)},
+	}
+
+	for _, c := range cases {
+		var got myradio.Time
+		if err := json.Unmarshal([]byte(c.j), &got); err != nil {
+			t.Error("unexpected error for", c.j, ":", err)
+			continue
+		}
+		if !got.Equal(c.e) {
+			t.Error("expected:", c.e, "got:", got, "for:", c.j)
+		}
+	}
+
+	// A null should leave the time alone.
+	got := myradio.Time{time.Unix(1239621071, 0)}
+	if err := json.Unmarshal([]byte(`null`), &got); err != nil {
+		t.Error("unexpected error for null:", err)
+	}
+	if !got.Equal(time.Unix(1239621071, 0)) {
+		t.Error("null changed time to", got)
+	}
+}
+
+// TestTimeUnmarshalError tests whether Time reports the offending value when it can't decode a time.
+func TestTimeUnmarshalError(t *testing.T) {
+	cases := []struct {
+		j   string
+		err string
+	}{
+		{j: `"yesterday"`, err: `Time.UnmarshalJSON: can't interpret "yesterday" as a time`},
+		{j: `true`, err: `Time.UnmarshalJSON: can't interpret true as a time`},
+	}
+
+	for _, c := range cases {
+		var got myradio.Time
+		err := json.Unmarshal([]byte(c.j), &got)
+		if err == nil {
+			t.Error("no error for", c.j, "was expecting one")
+			continue
+		}
+		if err.Error() != c.err {
+			t.Error("expected:", c.err, "got:", err.Error())
+		}
+	}
+}
+
+// TestGetScheduleRange tests whether GetScheduleRange filters, de-duplicates and sorts timeslots.
+// It does not test the API endpoint.
+func TestGetScheduleRange(t *testing.T) {
+	// The mock session gives back this schedule for every week we ask for.
+	session, err := myradio.MockSession([]byte(`{"1": [` + timeslotJSON + `]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		start, end time.Time
+		n          int
+	}{
+		{time.Date(2016, time.November, 14, 8, 30, 0, 0, time.Local), time.Date(2016, time.November, 30, 0, 0, 0, 0, time.Local), 1},
+		{time.Date(2016, time.December, 31, 0, 0, 0, 0, time.Local), time.Date(2017, time.January, 2, 0, 0, 0, 0, time.Local), 0},
+		{time.Date(2016, time.November, 14, 9, 30, 0, 0, time.Local), time.Date(2016, time.November, 15, 0, 0, 0, 0, time.Local), 0},
+	}
+
+	for _, c := range cases {
+		timeslots, err := session.GetScheduleRange(c.start, c.end)
+		if err != nil {
+			t.Error("unexpected error:", err)
+			continue
+		}
+		if len(timeslots) != c.n {
+			t.Error("expected", c.n, "timeslots between", c.start, "and", c.end, "got:", timeslots)
+		}
+	}
+
+	if _, err := session.GetScheduleRange(time.Unix(1, 0), time.Unix(0, 0)); err == nil {
+		t.Error("no error for backwards range, was expecting one")
+	}
+}
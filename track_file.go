@@ -0,0 +1,80 @@
+package myradio
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/UniversityRadioYork/myradio-go/api"
+)
+
+// Audio formats in which digitised tracks can be downloaded.
+const (
+	TrackFormatMP3 = "mp3"
+	TrackFormatOgg = "ogg"
+)
+
+// TrackFile contains file-level information about one format of a digitised track's audio.
+type TrackFile struct {
+	// TrackID is the ID of the track the file holds.
+	TrackID uint64 `json:"trackid"`
+	// Format is the audio format of the file, for example TrackFormatMP3.
+	Format string `json:"format"`
+	// Size is the size of the file, in bytes.
+	Size int64 `json:"size"`
+	// SHA256 is the hex-encoded SHA-256 checksum of the file.
+	SHA256 string `json:"sha256"`
+	// Bitrate is the bitrate of the audio, in bits per second.
+	Bitrate uint64 `json:"bitrate"`
+	// SampleRate is the sample rate of the audio, in hertz.
+	SampleRate uint64 `json:"sample_rate"`
+	// Channels is the number of audio channels.
+	Channels int `json:"channels"`
+}
+
+// GetTrackFile tries to get the file metadata for the given format of the track with the given ID.
+// This consumes one API request.
+func (s *Session) GetTrackFile(trackid uint64, format string) (file *TrackFile, err error) {
+	rq := api.NewRequestf("/track/%d/filemetadata", trackid)
+	rq.Params["format"] = []string{format}
+	err = s.do(rq).Into(&file)
+	return
+}
+
+// DownloadTrack streams the given format of the audio of the track with the given ID to w.
+// If offset is positive, the download starts that many bytes into the file, so that an interrupted download can be
+// resumed by passing the number of bytes already written.
+// A resumed download fails, writing nothing, if the server doesn't send back the file starting from offset.
+// It returns the number of bytes written to w.
+// This consumes one API request.
+func (s *Session) DownloadTrack(trackid uint64, format string, w io.Writer, offset int64) (int64, error) {
+	if offset < 0 {
+		return 0, fmt.Errorf("DownloadTrack: offset %d is negative", offset)
+	}
+
+	rq := api.NewRequestf("/track/%d/download", trackid)
+	rq.Params["format"] = []string{format}
+	if offset > 0 {
+		rq.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	return s.stream(rq, w)
+}
+
+// VerifyTrackFile checks that the contents of r match the size and checksum in f.
+// This consumes no API requests.
+func VerifyTrackFile(r io.Reader, f *TrackFile) error {
+	h := sha256.New()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return err
+	}
+	if n != f.Size {
+		return fmt.Errorf("VerifyTrackFile: track %d: expected %d bytes, got %d", f.TrackID, f.Size, n)
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(sum, f.SHA256) {
+		return fmt.Errorf("VerifyTrackFile: track %d: expected checksum %s, got %s", f.TrackID, f.SHA256, sum)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package myradio_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+// TestDownloadTrack tests whether DownloadTrack copies the raw response to the writer.
+func TestDownloadTrack(t *testing.T) {
+	audio := []byte("ID3 not really an mp3")
+	session, _ := myradio.MockSession(audio)
+
+	var buf bytes.Buffer
+	n, err := session.DownloadTrack(1, myradio.TrackFormatMP3, &buf, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(audio)) || !bytes.Equal(buf.Bytes(), audio) {
+		t.Errorf("expected:\n%q\n\ngot (%d bytes):\n%q", audio, n, buf.Bytes())
+	}
+}
+
+// TestVerifyTrackFile tests whether VerifyTrackFile catches files of the wrong size or checksum.
+func TestVerifyTrackFile(t *testing.T) {
+	f := &myradio.TrackFile{
+		TrackID: 1,
+		Size:    5,
+		SHA256:  "2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824",
+	}
+
+	if err := myradio.VerifyTrackFile(strings.NewReader("hello"), f); err != nil {
+		t.Error("unexpected error:", err)
+	}
+	if err := myradio.VerifyTrackFile(strings.NewReader("hell"), f); err == nil {
+		t.Error("expected an error for a short file")
+	}
+	if err := myradio.VerifyTrackFile(strings.NewReader("jello"), f); err == nil {
+		t.Error("expected an error for a bad checksum")
+	}
+}
@@ -0,0 +1,74 @@
+package myradio
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSearchTracks tests whether SearchTracks sends only the search terms and filters that are set.
+// It does not test the API endpoint.
+func TestSearchTracks(t *testing.T) {
+	cases := []struct {
+		search   TrackSearch
+		expected map[string][]string
+	}{
+		{
+			search:   TrackSearch{Title: "Jenny", Artist: "Tommy Tutone", Album: "Tommy Tutone 2", Text: "867-5309"},
+			expected: map[string][]string{"title": {"Jenny"}, "artist": {"Tommy Tutone"}, "album": {"Tommy Tutone 2"}, "term": {"867-5309"}},
+		},
+		{
+			search:   TrackSearch{Text: "Jenny", OnlyDigitised: true, OnlyClean: true, Limit: 10},
+			expected: map[string][]string{"term": {"Jenny"}, "digitised": {"true"}, "clean": {"true"}, "limit": {"10"}},
+		},
+	}
+
+	for _, c := range cases {
+		session, requester := scriptedSession(map[string][]string{
+			"/track/search": {`[{"trackid": 1, "title": "Jenny", "artist": "Tommy Tutone"}]`},
+		})
+		tracks, err := session.SearchTracks(c.search)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if len(tracks) != 1 || tracks[0].ID != 1 {
+			t.Error("expected track 1, got:", tracks)
+		}
+
+		sent := requester.sent()
+		if len(sent) != 1 {
+			t.Fatalf("expected 1 request, got %d", len(sent))
+		}
+		rq := sent[0]
+		if rq.Endpoint != "/track/search" {
+			t.Error("expected endpoint /track/search, got:", rq.Endpoint)
+		}
+		if expected := []string{"album"}; !reflect.DeepEqual(rq.Mixins, expected) {
+			t.Errorf("expected mixins %v, got %v", expected, rq.Mixins)
+		}
+		if !reflect.DeepEqual(rq.Params, c.expected) {
+			t.Errorf("expected:\n%v\n\ngot:\n%v", c.expected, rq.Params)
+		}
+	}
+}
+
+// TestSearchTracksValidation tests whether SearchTracks rejects bad searches without sending them.
+func TestSearchTracksValidation(t *testing.T) {
+	cases := []struct {
+		name   string
+		search TrackSearch
+	}{
+		{"empty", TrackSearch{}},
+		{"filters only", TrackSearch{OnlyDigitised: true, OnlyClean: true, Limit: 10}},
+		{"negative limit", TrackSearch{Title: "Jenny", Limit: -1}},
+	}
+
+	for _, c := range cases {
+		session, requester := scriptedSession(map[string][]string{})
+		if _, err := session.SearchTracks(c.search); err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+		if n := len(requester.sent()); n != 0 {
+			t.Errorf("%s: expected no requests, got %d", c.name, n)
+		}
+	}
+}
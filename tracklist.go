@@ -0,0 +1,91 @@
+package myradio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// FreeTextTrack describes a track that isn't in the track database, so that it can be logged in a tracklist.
+type FreeTextTrack struct {
+	// Title is the title of the track.
+	Title string
+	// Artist is the primary credited artist of the track.
+	Artist string
+	// Album is the album the track comes from, if known.
+	Album string
+	// Length is the length of the track, if known.
+	Length time.Duration
+}
+
+// Validate checks that the track has everything music reporting needs.
+func (t *FreeTextTrack) Validate() error {
+	if t.Title == "" {
+		return errors.New("FreeTextTrack: title is empty")
+	}
+	if t.Artist == "" {
+		return errors.New("FreeTextTrack: artist is empty")
+	}
+	if t.Length < 0 {
+		return fmt.Errorf("FreeTextTrack: length %v is negative", t.Length)
+	}
+	return nil
+}
+
+// LogTrack logs the library track with the given ID as played in the timeslot with the given ID.
+// It first checks that the track exists in the track database.
+// On success, it returns the new tracklist item.
+// This consumes two API requests.
+func (s *Session) LogTrack(timeslotID, trackID uint64) (item TracklistItem, err error) {
+	track, err := s.GetTrack(trackID)
+	if err != nil {
+		return
+	}
+	if track == nil {
+		err = fmt.Errorf("LogTrack: track %d is not in the track database", trackID)
+		return
+	}
+
+	err = s.post("/tracklistItem", map[string][]string{
+		"timeslotid": {strconv.FormatUint(timeslotID, 10)},
+		"trackid":    {strconv.FormatUint(trackID, 10)},
+	}).Into(&item)
+	return
+}
+
+// LogFreeTextTrack logs a track that isn't in the track database as played in the timeslot with the given ID.
+// On success, it returns the new tracklist item.
+// This consumes one API request.
+func (s *Session) LogFreeTextTrack(timeslotID uint64, track FreeTextTrack) (item TracklistItem, err error) {
+	if err = track.Validate(); err != nil {
+		return
+	}
+
+	params := map[string][]string{
+		"timeslotid": {strconv.FormatUint(timeslotID, 10)},
+		"title":      {track.Title},
+		"artist":     {track.Artist},
+		"album":      {track.Album},
+	}
+	if track.Length != 0 {
+		params["length"] = []string{formatDuration(track.Length)}
+	}
+	err = s.post("/tracklistItem", params).Into(&item)
+	return
+}
+
+// EndTracklistItem marks the tracklist item with the given audio log ID as having finished playing now.
+// This consumes one API request.
+func (s *Session) EndTracklistItem(audioLogID uint) error {
+	_, err := s.putf("/tracklistItem/%d/endtime", bytes.Buffer{}, audioLogID).JSON()
+	return err
+}
+
+// DeleteTracklistItem removes the tracklist item with the given audio log ID from its tracklist.
+// This consumes one API request.
+func (s *Session) DeleteTracklistItem(audioLogID uint) error {
+	_, err := s.deletef("/tracklistItem/%d", audioLogID).JSON()
+	return err
+}
@@ -0,0 +1,115 @@
+package myradio_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+const tracklistItemJSON = `{
+	"trackid": 7,
+	"title": "Toast",
+	"artist": "Tommy",
+	"length": "00:03:30",
+	"time": 1479081600,
+	"starttime": "14/11/2016 00:00:00",
+	"audiologid": 42
+}`
+
+// TestFreeTextTrackValidate tests whether FreeTextTrack.Validate rejects tracks music reporting can't use.
+func TestFreeTextTrackValidate(t *testing.T) {
+	cases := []struct {
+		track myradio.FreeTextTrack
+		ok    bool
+	}{
+		{myradio.FreeTextTrack{Title: "Toast", Artist: "Tommy"}, true},
+		{myradio.FreeTextTrack{Title: "Toast", Artist: "Tommy", Album: "Breakfast", Length: 3 * time.Minute}, true},
+		{myradio.FreeTextTrack{Artist: "Tommy"}, false},
+		{myradio.FreeTextTrack{Title: "Toast"}, false},
+		{myradio.FreeTextTrack{Title: "Toast", Artist: "Tommy", Length: -time.Second}, false},
+	}
+	for _, c := range cases {
+		if err := c.track.Validate(); (err == nil) != c.ok {
+			t.Errorf("%v: expected ok=%v, got error: %v", c.track, c.ok, err)
+		}
+	}
+}
+
+// TestLogTrack tests the unmarshalling of the tracklist items returned by LogTrack and LogFreeTextTrack.
+// It does not test the API endpoint.
+func TestLogTrack(t *testing.T) {
+	session, err := myradio.MockSession([]byte(tracklistItemJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := myradio.TracklistItem{
+		Track: myradio.Track{
+			ID:          7,
+			Title:       "Toast",
+			Artist:      "Tommy",
+			Length:      "00:03:30",
+			Duration:    3*time.Minute + 30*time.Second,
+			LengthValid: true,
+		},
+		Time:         time.Unix(1479081600, 0),
+		TimeRaw:      1479081600,
+		StartTime:    time.Date(2016, time.November, 14, 0, 0, 0, 0, time.UTC),
+		StartTimeRaw: "14/11/2016 00:00:00",
+		AudioLogID:   42,
+	}
+
+	item, err := session.LogTrack(1, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(item, expected) {
+		t.Errorf("LogTrack: expected:\n%v\n\ngot:\n%v", expected, item)
+	}
+
+	item, err = session.LogFreeTextTrack(1, myradio.FreeTextTrack{Title: "Toast", Artist: "Tommy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(item, expected) {
+		t.Errorf("LogFreeTextTrack: expected:\n%v\n\ngot:\n%v", expected, item)
+	}
+
+	if _, err := session.LogFreeTextTrack(1, myradio.FreeTextTrack{Title: "Toast"}); err == nil {
+		t.Error("LogFreeTextTrack: expected an error for a track with no artist")
+	}
+}
+
+// TestEndAndDeleteTracklistItem tests that ending and deleting tracklist items accept an empty response.
+// It does not test the API endpoint.
+func TestEndAndDeleteTracklistItem(t *testing.T) {
+	session, err := myradio.MockSession([]byte(`null`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := session.EndTracklistItem(42); err != nil {
+		t.Error("EndTracklistItem: unexpected error:", err)
+	}
+	if err := session.DeleteTracklistItem(42); err != nil {
+		t.Error("DeleteTracklistItem: unexpected error:", err)
+	}
+}
+
+// TestTracklistItemAlbum tests that a tracklist item's album is decoded once, into the item rather than its Track.
+func TestTracklistItemAlbum(t *testing.T) {
+	var item myradio.TracklistItem
+	err := json.Unmarshal([]byte(`{"trackid": 7, "title": "Toast", "album": {"recordid": 3, "title": "Breakfast"}, "starttime": "14/11/2016 00:00:00"}`), &item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Album.ID != 3 || item.Album.Title != "Breakfast" {
+		t.Error("expected album 3 (Breakfast), got:", item.Album)
+	}
+	if item.Track.Album != nil {
+		t.Error("expected the embedded track to have no album, got:", item.Track.Album)
+	}
+}
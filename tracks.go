@@ -1,9 +1,12 @@
 package myradio
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/UniversityRadioYork/myradio-go/api"
 )
@@ -36,9 +39,9 @@ type Album struct {
 	ShelfNumber uint64 `json:"shelf_number"`
 
 	// Format is a single-character code identifying the physical format.
-	Format string `json:"format"`
+	Format AlbumFormat `json:"format"`
 	// Medium is a single-character code identifying the physical medium.
-	Medium string `json:"media"`
+	Medium AlbumMedium `json:"media"`
 
 	// AddingMember is the ID of the member who added this album.
 	AddingMember uint64 `json:"member_add"`
@@ -49,7 +52,7 @@ type Album struct {
 	RecordLabel string `json:"record_label"`
 
 	// Status is the digitisation status code for this album.
-	Status string `json:"status"`
+	Status AlbumStatus `json:"status"`
 }
 
 // Track contains information about a track in the URY track database.
@@ -63,14 +66,42 @@ type Track struct {
 	Artist string `json:"artist"`
 	// Type is the type ('central' etc.) of the track.
 	Type string `json:"type"`
-	// Length is the length of the track, in hours:minutes:seconds.
+	// Length is the raw length of the track, in hours:minutes:seconds.
 	Length string `json:"length"`
+	// Duration is the length of the track, parsed from Length whenever the track is decoded.
+	// It is zero if the track has no length, or if Length couldn't be parsed.
+	Duration time.Duration `json:"-"`
+	// LengthValid is true if Length was present and parsed into Duration when the track was decoded.
+	LengthValid bool `json:"-"`
 	// Intro is length of the track's intro, in seconds.
 	Intro uint64 `json:"intro"`
 	// IsClean is true if this track is clean (no expletives).
 	IsClean bool `json:"clean"`
 	// IsDigitised is true if this track is available in the playout system.
 	IsDigitised bool `json:"digitised"`
+	// Album is the album the track comes from, if the API sent it along with the track.
+	Album *Album `json:"album,omitempty"`
+}
+
+// UnmarshalJSON decodes a Track, then parses its length.
+// A missing or malformed length doesn't stop the decode; it leaves Duration at zero and LengthValid false.
+func (t *Track) UnmarshalJSON(b []byte) (err error) {
+	type track Track
+	if err = json.Unmarshal(b, (*track)(t)); err != nil {
+		return
+	}
+	t.populateTrackLength()
+	return
+}
+
+// populateTrackLength sets the Duration and LengthValid of the given Track from its raw Length.
+func (t *Track) populateTrackLength() {
+	dur, err := parseDuration(t.Length)
+	t.LengthValid = t.Length != "" && err == nil
+	t.Duration = 0
+	if t.LengthValid {
+		t.Duration = dur
+	}
 }
 
 // GetAlbum tries to get the Album for the given Track.
@@ -83,14 +114,15 @@ func (t *Track) GetAlbum(s *Session) (*Album, error) {
 // Returns an error if the track's length is ill-formed.
 // This consumes no API requests.
 func (t *Track) LengthSec() (uint64, error) {
-	var hours, minutes, seconds uint64
-
-	_, err := fmt.Sscan(strings.Replace(t.Length, ":", " ", -1), &hours, &minutes, &seconds)
+	dur, err := parseDuration(t.Length)
 	if err != nil {
 		return 0, err
 	}
+	if dur < 0 {
+		return 0, fmt.Errorf("LengthSec: length %s is negative", t.Length)
+	}
 
-	return (hours * 60 * 60) + (minutes * 60) + seconds, nil
+	return uint64(dur / time.Second), nil
 }
 
 // LengthUsec returns the track's length in microseconds.
@@ -113,6 +145,12 @@ func (t *Track) IntroUsec() uint64 {
 	return t.Intro * 1000000
 }
 
+// IntroDuration returns the length of the track's intro.
+// This consumes no API requests.
+func (t *Track) IntroDuration() time.Duration {
+	return time.Duration(t.Intro) * time.Second
+}
+
 // GetTrack tries to get the Track with the given ID.
 // Track IDs are unique, so we do not need the record ID.
 // This consumes one API request.
@@ -135,6 +173,61 @@ func (s *Session) GetTrackAlbum(trackid uint64) (album *Album, err error) {
 	return
 }
 
+// TrackSearch describes a search of the central track library.
+// Every non-empty text field must match for a track to be found.
+type TrackSearch struct {
+	// Title matches against track titles.
+	Title string
+	// Artist matches against track artists.
+	Artist string
+	// Album matches against album titles.
+	Album string
+	// Text matches against track titles, artists and album titles at once.
+	Text string
+	// OnlyDigitised restricts the search to tracks available in the playout system.
+	OnlyDigitised bool
+	// OnlyClean restricts the search to tracks with no expletives.
+	OnlyClean bool
+	// Limit is the maximum number of tracks to return; 0 leaves it up to MyRadio.
+	Limit int
+}
+
+// SearchTracks searches the central track library, returning matching tracks along with their albums.
+// This consumes one API request.
+func (s *Session) SearchTracks(search TrackSearch) (tracks []Track, err error) {
+	if search.Title == "" && search.Artist == "" && search.Album == "" && search.Text == "" {
+		return nil, errors.New("SearchTracks: nothing to search for")
+	}
+	if search.Limit < 0 {
+		return nil, fmt.Errorf("SearchTracks: limit %d is negative", search.Limit)
+	}
+
+	rq := api.NewRequest("/track/search")
+	rq.Mixins = []string{"album"}
+	for k, v := range map[string]string{
+		"title":  search.Title,
+		"artist": search.Artist,
+		"album":  search.Album,
+		"term":   search.Text,
+	} {
+		if v != "" {
+			rq.Params[k] = []string{v}
+		}
+	}
+	if search.OnlyDigitised {
+		rq.Params["digitised"] = []string{"true"}
+	}
+	if search.OnlyClean {
+		rq.Params["clean"] = []string{"true"}
+	}
+	if search.Limit != 0 {
+		rq.Params["limit"] = []string{strconv.Itoa(search.Limit)}
+	}
+
+	err = s.do(rq).Into(&tracks)
+	return
+}
+
 // GetTimeslotMetadata gets a metadata key for the timeslot.
 // Be careful.
 // Returns nil err and an empty string if the key does not exist, err if something went wrong.
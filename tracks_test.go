@@ -0,0 +1,71 @@
+package myradio_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+// TestTrackLength tests whether tracks get their lengths parsed, and validated, when decoded.
+func TestTrackLength(t *testing.T) {
+	var track myradio.Track
+	if err := json.Unmarshal([]byte(`{"trackid": 1, "length": "00:04:55", "intro": 12}`), &track); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !track.LengthValid || track.Duration != 4*time.Minute+55*time.Second {
+		t.Error("expected duration 4m55s, got:", track.Duration)
+	}
+	if track.IntroDuration() != 12*time.Second {
+		t.Error("expected intro 12s, got:", track.IntroDuration())
+	}
+	if secs, err := track.LengthSec(); err != nil || secs != 295 {
+		t.Error("expected 295 seconds, got:", secs, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"trackid": 2, "length": ""}`), &track); err != nil {
+		t.Error("unexpected error for missing length:", err)
+	}
+	if track.Duration != 0 || track.LengthValid {
+		t.Error("expected no valid duration, got:", track.Duration, track.LengthValid)
+	}
+
+	for _, bad := range []string{"1 2 3", "00:61:00"} {
+		if err := json.Unmarshal([]byte(`{"trackid": 3, "length": "`+bad+`"}`), &track); err != nil {
+			t.Error("unexpected error decoding length", bad, ":", err)
+		}
+		if track.LengthValid || track.Duration != 0 {
+			t.Error("expected length", bad, "to be invalid, got:", track.Duration, track.LengthValid)
+		}
+		if _, err := track.LengthSec(); err == nil {
+			t.Error("no error getting seconds of length", bad, "was expecting one")
+		}
+	}
+}
+
+// TestTracklistBadLength tests whether a tracklist still decodes when one of its tracks has a malformed length.
+// It does not test the API endpoint.
+func TestTracklistBadLength(t *testing.T) {
+	session, err := myradio.MockSession([]byte(`[
+		{"trackid": 1, "title": "Jenny", "length": "1 2 3", "starttime": "14/11/2016 00:00:00"},
+		{"trackid": 2, "title": "Africa", "length": "00:04:55", "starttime": "14/11/2016 00:04:00"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tracklist, err := session.GetTrackListForTimeslot(1)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(tracklist) != 2 {
+		t.Fatal("expected 2 items, got:", len(tracklist))
+	}
+	if tracklist[0].LengthValid || tracklist[0].Length != "1 2 3" {
+		t.Error("expected item 0 to keep its bad length, and not be valid, got:", tracklist[0].Track)
+	}
+	if !tracklist[1].LengthValid || tracklist[1].Duration != 4*time.Minute+55*time.Second {
+		t.Error("expected item 1 to last 4m55s, got:", tracklist[1].Track)
+	}
+}
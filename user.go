@@ -1,7 +1,16 @@
 package myradio
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/mail"
+	"net/url"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/UniversityRadioYork/myradio-go/api"
@@ -13,31 +22,68 @@ type User struct {
 	Fname, Sname string
 	Email        string `json:"public_email"`
 	Receiveemail bool   `json:"receive_email"`
+	// PersonalEmail and Eduroam are only sent with the personal_data mixin.
+	PersonalEmail string `json:"email,omitempty"`
+	Eduroam       string `json:"eduroam,omitempty"`
 	//@TODO: fix the api and make it return a photo object
 	Photo string
 	Bio   string
 }
 
 // Officership represents an officership a user holds.
+// FromDate and TillDate are derived from their raw fields whenever an Officership is decoded,
+// and are not themselves encoded.
 type Officership struct {
-	OfficerId   uint   `json:"officerid,string"`
-	OfficerName string `json:"officer_name"`
-	TeamId      uint   `json:"teamid,string"`
-	FromDateRaw string `json:"from_date,omitempty"`
-	FromDate    time.Time
-	TillDateRaw string `json:"till_date,omitempty"`
-	TillDate    time.Time
+	OfficerId   uint            `json:"officerid,string"`
+	OfficerName string          `json:"officer_name"`
+	TeamId      uint            `json:"teamid,string"`
+	FromDateRaw string          `json:"from_date,omitempty"`
+	FromDate    time.Time       `json:"-"`
+	TillDateRaw string          `json:"till_date,omitempty"`
+	TillDate    time.Time       `json:"-"`
 	Officer     OfficerPosition `json:"officer"`
 }
 
+// UnmarshalJSON decodes an Officership, then populates its dates from their raw values.
+func (o *Officership) UnmarshalJSON(b []byte) (err error) {
+	type officership Officership
+	if err = json.Unmarshal(b, (*officership)(o)); err != nil {
+		return
+	}
+
+	if o.FromDateRaw != "" {
+		if o.FromDate, err = time.Parse("2006-01-02", o.FromDateRaw); err != nil {
+			return
+		}
+	}
+	if o.TillDateRaw != "" {
+		o.TillDate, err = time.Parse("2006-01-02", o.TillDateRaw)
+	}
+	return
+}
+
 // Photo represents a photo of a user.
+// DateAdded is derived from DateAddedRaw whenever a Photo is decoded, and is not itself encoded.
 type Photo struct {
-	PhotoId      uint   `json:"photoid"`
-	DateAddedRaw string `json:"date_added"`
-	DateAdded    time.Time
-	Format       string `json:"format"`
-	Owner        uint   `json:"owner"`
-	Url          string `json:"url"`
+	PhotoId      uint      `json:"photoid"`
+	DateAddedRaw string    `json:"date_added"`
+	DateAdded    time.Time `json:"-"`
+	Format       string    `json:"format"`
+	Owner        uint      `json:"owner"`
+	Url          string    `json:"url"`
+}
+
+// UnmarshalJSON decodes a Photo, then populates its date from its raw value.
+func (p *Photo) UnmarshalJSON(b []byte) (err error) {
+	type photo Photo
+	if err = json.Unmarshal(b, (*photo)(p)); err != nil {
+		return
+	}
+
+	if p.DateAddedRaw != "" {
+		p.DateAdded, err = time.Parse("02/01/2006 15:04", p.DateAddedRaw)
+	}
+	return
 }
 
 // UserAlias represents a user alias.
@@ -78,6 +124,38 @@ func (s *Session) GetUserBio(id int) (bio string, err error) {
 	return
 }
 
+// SetUserBio replaces the biography of the user with the given ID.
+// This consumes one API request.
+func (s *Session) SetUserBio(id int, bio string) error {
+	body := bytes.NewBufferString(url.Values{"bio": {bio}}.Encode())
+	_, err := s.putf("/user/%d/bio", *body, id).JSON()
+	return err
+}
+
+// SetUserPublicEmail sets the email address shown publicly for the user with the given ID.
+// An empty email clears the public address.
+// The email must be a bare address, such as "jo@example.org", with no display name or angle brackets.
+// This consumes one API request.
+func (s *Session) SetUserPublicEmail(id int, email string) error {
+	if email != "" {
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			return fmt.Errorf("SetUserPublicEmail: %q is not an email address", email)
+		}
+	}
+
+	body := bytes.NewBufferString(url.Values{"public_email": {email}}.Encode())
+	_, err := s.putf("/user/%d/publicemail", *body, id).JSON()
+	return err
+}
+
+// SetUserReceiveEmail sets whether the user with the given ID receives email from the station's mailing lists.
+// This consumes one API request.
+func (s *Session) SetUserReceiveEmail(id int, receive bool) error {
+	body := bytes.NewBufferString(url.Values{"receive_email": {strconv.FormatBool(receive)}}.Encode())
+	_, err := s.putf("/user/%d/receiveemail", *body, id).JSON()
+	return err
+}
+
 // GetUserName retrieves the name of the user with the given ID.
 // This consumes one API request.
 func (s *Session) GetUserName(id int) (name string, err error) {
@@ -94,10 +172,35 @@ func (s *Session) GetUserProfilePhoto(id int) (profilephoto Photo, err error) {
 		return
 	}
 	err = rs.Into(&profilephoto)
+	return
+}
+
+// UploadUserProfilePhoto uploads the image read from photo as the new profile photo of the user with the given ID,
+// returning the resulting Photo.
+// The filename is passed to MyRadio so it can tell the image's format from its extension.
+// This consumes one API request.
+func (s *Session) UploadUserProfilePhoto(id int, filename string, photo io.Reader) (profilephoto Photo, err error) {
+	if filepath.Ext(filename) == "" {
+		err = fmt.Errorf("UploadUserProfilePhoto: filename %q has no extension", filename)
+		return
+	}
+
+	rq := api.NewRequestf("/user/%d/profilephoto", id)
+	rq.ReqType = api.PostReq
+	mw := multipart.NewWriter(&rq.Body)
+	part, err := mw.CreateFormFile("photo", filepath.Base(filename))
 	if err != nil {
 		return
 	}
-	profilephoto.DateAdded, err = time.Parse("02/01/2006 15:04", profilephoto.DateAddedRaw)
+	if _, err = io.Copy(part, photo); err != nil {
+		return
+	}
+	if err = mw.Close(); err != nil {
+		return
+	}
+	rq.Header.Set("Content-Type", mw.FormDataContentType())
+
+	err = s.do(rq).Into(&profilephoto)
 	return
 }
 
@@ -105,23 +208,6 @@ func (s *Session) GetUserProfilePhoto(id int) (profilephoto Photo, err error) {
 // This consumes one API request.
 func (s *Session) GetUserOfficerships(id int) (officerships []Officership, err error) {
 	err = s.getf("/user/%d/officerships/", id).Into(&officerships)
-	if err != nil {
-		return
-	}
-	for k, v := range officerships {
-		if officerships[k].FromDateRaw != "" {
-			officerships[k].FromDate, err = time.Parse("2006-01-02", v.FromDateRaw)
-			if err != nil {
-				return
-			}
-		}
-		if officerships[k].TillDateRaw != "" {
-			officerships[k].TillDate, err = time.Parse("2006-01-02", v.TillDateRaw)
-			if err != nil {
-				return
-			}
-		}
-	}
 	return
 }
 
@@ -0,0 +1,119 @@
+package myradio
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/UniversityRadioYork/myradio-go/api"
+)
+
+// ErrUserNotFound is returned by LookupUser when no user matches the query.
+var ErrUserNotFound = errors.New("no user matches the query")
+
+// searchUsers fetches one page of users matching query from the given search endpoint.
+// A limit of 0 leaves the page size up to MyRadio.
+func (s *Session) searchUsers(endpoint, param, query string, limit, offset int) (users []User, err error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("nothing to search for")
+	}
+	if limit < 0 || offset < 0 {
+		return nil, fmt.Errorf("limit %d and offset %d must not be negative", limit, offset)
+	}
+
+	rq := api.NewRequest(endpoint)
+	rq.Mixins = []string{"personal_data"}
+	rq.Params[param] = []string{query}
+	if limit > 0 {
+		rq.Params["limit"] = []string{strconv.Itoa(limit)}
+	}
+	if offset > 0 {
+		rq.Params["offset"] = []string{strconv.Itoa(offset)}
+	}
+	err = s.do(rq).Into(&users)
+	return
+}
+
+// SearchUsersByName retrieves up to limit users, skipping the first offset, whose names match name.
+// The name may be a first name, a surname, or both.
+// This consumes one API request.
+func (s *Session) SearchUsersByName(name string, limit, offset int) ([]User, error) {
+	users, err := s.searchUsers("/user/findbyname", "name", name, limit, offset)
+	if err != nil {
+		err = fmt.Errorf("SearchUsersByName: %v", err)
+	}
+	return users, err
+}
+
+// SearchUsersByEmail retrieves up to limit users, skipping the first offset, whose email addresses match email.
+// This consumes one API request.
+func (s *Session) SearchUsersByEmail(email string, limit, offset int) ([]User, error) {
+	users, err := s.searchUsers("/user/findbyemail", "email", email, limit, offset)
+	if err != nil {
+		err = fmt.Errorf("SearchUsersByEmail: %v", err)
+	}
+	return users, err
+}
+
+// SearchUsersByUsername retrieves up to limit users, skipping the first offset, whose university usernames match
+// username.
+// This consumes one API request.
+func (s *Session) SearchUsersByUsername(username string, limit, offset int) ([]User, error) {
+	users, err := s.searchUsers("/user/findbyeduroam", "eduroam", username, limit, offset)
+	if err != nil {
+		err = fmt.Errorf("SearchUsersByUsername: %v", err)
+	}
+	return users, err
+}
+
+// LookupUser finds the one user whose email address, university username or full name exactly matches query,
+// ignoring case.
+// Queries containing '@' are treated as email addresses, queries containing spaces as full names,
+// and anything else as a university username.
+// It returns ErrUserNotFound if nobody matches, and an error if more than one user does.
+// This consumes one API request.
+func (s *Session) LookupUser(query string) (*User, error) {
+	query = strings.TrimSpace(query)
+
+	var (
+		users []User
+		err   error
+		match func(u *User) bool
+	)
+	switch {
+	case strings.Contains(query, "@"):
+		users, err = s.SearchUsersByEmail(query, 0, 0)
+		match = func(u *User) bool {
+			return strings.EqualFold(u.PersonalEmail, query) || strings.EqualFold(u.Email, query)
+		}
+	case strings.Contains(query, " "):
+		users, err = s.SearchUsersByName(query, 0, 0)
+		match = func(u *User) bool {
+			return strings.EqualFold(strings.Join(strings.Fields(u.Fname+" "+u.Sname), " "), strings.Join(strings.Fields(query), " "))
+		}
+	default:
+		users, err = s.SearchUsersByUsername(query, 0, 0)
+		match = func(u *User) bool {
+			return strings.EqualFold(u.Eduroam, query)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var found *User
+	for i := range users {
+		if !match(&users[i]) {
+			continue
+		}
+		if found != nil && found.MemberID != users[i].MemberID {
+			return nil, fmt.Errorf("LookupUser: %q matches more than one user", query)
+		}
+		found = &users[i]
+	}
+	if found == nil {
+		return nil, ErrUserNotFound
+	}
+	return found, nil
+}
@@ -0,0 +1,56 @@
+package myradio_test
+
+import (
+	"reflect"
+	"testing"
+
+	myradio "github.com/UniversityRadioYork/myradio-go"
+)
+
+const searchUsersJSON = `[
+	{"memberid": 1, "fname": "Jo", "sname": "Bloggs", "public_email": "", "email": "jb123@york.ac.uk", "eduroam": "jb123"},
+	{"memberid": 2, "fname": "Jo", "sname": "Bloggsworth", "public_email": "", "email": "jb456@york.ac.uk", "eduroam": "jb456"}
+]`
+
+// TestLookupUser tests whether LookupUser picks the exact match out of a set of search results.
+func TestLookupUser(t *testing.T) {
+	session, err := myradio.MockSession([]byte(searchUsersJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, query := range []string{"JB123@york.ac.uk", "jb123", "jo  bloggs"} {
+		user, err := session.LookupUser(query)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", query, err)
+			continue
+		}
+		if user.MemberID != 1 {
+			t.Errorf("%q: expected member 1, got %d", query, user.MemberID)
+		}
+	}
+
+	if _, err := session.LookupUser("jb789"); err != myradio.ErrUserNotFound {
+		t.Errorf("expected ErrUserNotFound, got: %v", err)
+	}
+}
+
+// TestSearchUsersByName tests whether SearchUsersByName decodes personal data.
+func TestSearchUsersByName(t *testing.T) {
+	session, err := myradio.MockSession([]byte(searchUsersJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := session.SearchUsersByName("Jo", 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []myradio.User{
+		{MemberID: 1, Fname: "Jo", Sname: "Bloggs", PersonalEmail: "jb123@york.ac.uk", Eduroam: "jb123"},
+		{MemberID: 2, Fname: "Jo", Sname: "Bloggsworth", PersonalEmail: "jb456@york.ac.uk", Eduroam: "jb456"},
+	}
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("expected:\n%v\n\ngot:\n%v", expected, users)
+	}
+}
@@ -0,0 +1,147 @@
+package myradio
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/UniversityRadioYork/myradio-go/api"
+)
+
+// TestSetUserDetails tests whether the user detail setters send their forms to the right endpoints.
+// It does not test the API endpoint.
+func TestSetUserDetails(t *testing.T) {
+	cases := []struct {
+		name     string
+		call     func(s *Session) error
+		endpoint string
+		form     url.Values
+	}{
+		{
+			name:     "bio",
+			call:     func(s *Session) error { return s.SetUserBio(7, "Tea & toast") },
+			endpoint: "/user/7/bio",
+			form:     url.Values{"bio": {"Tea & toast"}},
+		},
+		{
+			name:     "public email",
+			call:     func(s *Session) error { return s.SetUserPublicEmail(7, "jo@example.org") },
+			endpoint: "/user/7/publicemail",
+			form:     url.Values{"public_email": {"jo@example.org"}},
+		},
+		{
+			name:     "clear public email",
+			call:     func(s *Session) error { return s.SetUserPublicEmail(7, "") },
+			endpoint: "/user/7/publicemail",
+			form:     url.Values{"public_email": {""}},
+		},
+		{
+			name:     "receive email",
+			call:     func(s *Session) error { return s.SetUserReceiveEmail(7, true) },
+			endpoint: "/user/7/receiveemail",
+			form:     url.Values{"receive_email": {"true"}},
+		},
+	}
+
+	for _, c := range cases {
+		session, requester := scriptedSession(map[string][]string{})
+		if err := c.call(session); err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+
+		sent := requester.sent()
+		if len(sent) != 1 {
+			t.Errorf("%s: expected 1 request, got %d", c.name, len(sent))
+			continue
+		}
+		rq := sent[0]
+		if rq.Endpoint != c.endpoint || rq.ReqType != api.PutReq {
+			t.Errorf("%s: expected PUT to %s, got %v to %s", c.name, c.endpoint, rq.ReqType, rq.Endpoint)
+		}
+		form, err := url.ParseQuery(rq.Body.String())
+		if err != nil {
+			t.Errorf("%s: body %q isn't a form: %v", c.name, rq.Body.String(), err)
+		} else if !reflect.DeepEqual(form, c.form) {
+			t.Errorf("%s: expected:\n%v\n\ngot:\n%v", c.name, c.form, form)
+		}
+	}
+}
+
+// TestSetUserPublicEmailInvalid tests whether SetUserPublicEmail rejects anything but a bare address without
+// sending it.
+func TestSetUserPublicEmailInvalid(t *testing.T) {
+	for _, email := range []string{"jo", "jo@", "Jo Bloggs <jo@example.org>", "<jo@example.org>"} {
+		session, requester := scriptedSession(map[string][]string{})
+		if err := session.SetUserPublicEmail(7, email); err == nil {
+			t.Errorf("%q: expected an error", email)
+		}
+		if n := len(requester.sent()); n != 0 {
+			t.Errorf("%q: expected no requests, got %d", email, n)
+		}
+	}
+}
+
+// TestUploadUserProfilePhoto tests whether UploadUserProfilePhoto posts the photo as a multipart form.
+// It does not test the API endpoint.
+func TestUploadUserProfilePhoto(t *testing.T) {
+	session, requester := scriptedSession(map[string][]string{
+		"/user/7/profilephoto": {`{"photoid": 3, "date_added": "14/11/2016 08:00", "format": "png", "owner": 7}`},
+	})
+
+	photo, err := session.UploadUserProfilePhoto(7, "/home/jo/Pictures/me.png", strings.NewReader("not really a PNG"))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if photo.PhotoId != 3 || photo.Format != "png" || photo.Owner != 7 {
+		t.Error("photo decoded incorrectly:", photo)
+	}
+
+	sent := requester.sent()
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(sent))
+	}
+	rq := sent[0]
+	if rq.Endpoint != "/user/7/profilephoto" || rq.ReqType != api.PostReq {
+		t.Errorf("expected POST to /user/7/profilephoto, got %v to %s", rq.ReqType, rq.Endpoint)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(rq.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal("bad Content-Type:", err)
+	}
+	if mediaType != "multipart/form-data" || params["boundary"] == "" {
+		t.Fatalf("expected multipart/form-data with a boundary, got %q", rq.Header.Get("Content-Type"))
+	}
+
+	mr := multipart.NewReader(bytes.NewReader(rq.Body.Bytes()), params["boundary"])
+	part, err := mr.NextPart()
+	if err != nil {
+		t.Fatal("expected a part, got:", err)
+	}
+	if part.FormName() != "photo" || part.FileName() != "me.png" {
+		t.Errorf("expected part photo with filename me.png, got %q with filename %q", part.FormName(), part.FileName())
+	}
+	if b, err := ioutil.ReadAll(part); err != nil || string(b) != "not really a PNG" {
+		t.Errorf("expected photo contents %q, got %q (%v)", "not really a PNG", b, err)
+	}
+	if _, err := mr.NextPart(); err == nil {
+		t.Error("expected only one part")
+	}
+}
+
+// TestUploadUserProfilePhotoNoExtension tests whether UploadUserProfilePhoto refuses filenames with no extension.
+func TestUploadUserProfilePhotoNoExtension(t *testing.T) {
+	session, requester := scriptedSession(map[string][]string{})
+	if _, err := session.UploadUserProfilePhoto(7, "me", strings.NewReader("")); err == nil {
+		t.Error("expected an error")
+	}
+	if n := len(requester.sent()); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+}
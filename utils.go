@@ -73,3 +73,16 @@ func parseDuration(value string) (dur time.Duration, err error) {
 	dur = time.Duration(sign) * ((time.Duration(h) * time.Hour) + (time.Duration(m) * time.Minute) + (time.Duration(s) * time.Second))
 	return
 }
+
+// formatDuration formats durations in MyRadio's 'HH:MM:SS' format, rounding down to the second.
+// It is the inverse of parseDuration.
+func formatDuration(dur time.Duration) string {
+	sign := ""
+	if dur < 0 {
+		sign = "-"
+		dur = -dur
+	}
+
+	secs := int64(dur / time.Second)
+	return fmt.Sprintf("%s%02d:%02d:%02d", sign, secs/3600, (secs/60)%60, secs%60)
+}
@@ -79,3 +79,27 @@ func TestParseDurationError(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		expected string
+		durStr   string
+	}{
+		{"02:00:00", "2h"},
+		{"00:30:00", "30m"},
+		{"30:00:00", "30h"},
+		{"-00:00:05", "-5s"},
+		{"00:01:01", "1m1.5s"},
+	}
+
+	for _, test := range tests {
+		dur, _ := time.ParseDuration(test.durStr)
+		got := formatDuration(dur)
+		if got != test.expected {
+			t.Error("expected:", test.expected, "got:", got)
+		}
+		if back, err := parseDuration(got); err != nil || back != dur.Truncate(time.Second) {
+			t.Error("couldn't parse", got, "back into", dur, "got:", back, err)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package myradio
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultPollInterval is the interval at which watchers poll when constructed with an interval of zero or less.
+const DefaultPollInterval = 10 * time.Second
+
+// pollInterval gets the interval a watcher constructed with interval d should poll at.
+// Without this, a zero or negative interval would have the watcher poll MyRadio as fast as it can.
+func pollInterval(d time.Duration) time.Duration {
+	if d <= 0 {
+		return DefaultPollInterval
+	}
+	return d
+}
+
+// poll calls f straight away, then again after each delay f returns, until ctx is done or f fails.
+// It returns f's error, or ctx's if ctx finished first.
+func poll(ctx context.Context, f func() (time.Duration, error)) error {
+	for {
+		delay, err := f()
+		if err != nil {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
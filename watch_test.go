@@ -0,0 +1,146 @@
+package myradio
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestWatcherIntervals tests whether every watcher falls back to DefaultPollInterval when given an interval of zero
+// or less, rather than polling as fast as it can.
+func TestWatcherIntervals(t *testing.T) {
+	s := &Session{}
+	intervals := map[string]func(time.Duration) time.Duration{
+		"CurrentAndNext": func(d time.Duration) time.Duration { return s.NewCurrentAndNextWatcher(d).interval },
+		"Message":        func(d time.Duration) time.Duration { return s.NewMessageWatcher(1, 0, d).interval },
+		"NowPlaying":     func(d time.Duration) time.Duration { return s.NewNowPlayingWatcher(d, false).interval },
+		"Schedule":       func(d time.Duration) time.Duration { return s.NewScheduleWatcher(2016, 46, d).interval },
+		"Selector":       func(d time.Duration) time.Duration { return s.NewSelectorWatcher(d).interval },
+	}
+	cases := []struct {
+		interval, expected time.Duration
+	}{
+		{interval: 0, expected: DefaultPollInterval},
+		{interval: -time.Second, expected: DefaultPollInterval},
+		{interval: 5 * time.Second, expected: 5 * time.Second},
+	}
+
+	for name, interval := range intervals {
+		for _, c := range cases {
+			if got := interval(c.interval); got != c.expected {
+				t.Errorf("%s watcher with interval %v: expected %v, got %v", name, c.interval, c.expected, got)
+			}
+		}
+	}
+}
+
+// weekScheduleJSON gets a week schedule with one timeslot on Monday, with the given title.
+func weekScheduleJSON(title string) string {
+	return fmt.Sprintf(`{"1": [{
+		"show_id": 1,
+		"title": %q,
+		"season_id": 2,
+		"submitted": "01/09/2016 12:00",
+		"first_time": "Not Scheduled",
+		"timeslot_id": 3,
+		"timeslot_num": 4,
+		"time": 1479081600,
+		"start_time": "14/11/2016 08:00",
+		"duration": "01:30:00"
+	}]}`, title)
+}
+
+// TestScheduleWatcher tests whether a ScheduleWatcher reports only changes to the schedule, and stops cleanly.
+// It does not test the API endpoint.
+func TestScheduleWatcher(t *testing.T) {
+	before, after := weekScheduleJSON("Breakfast"), weekScheduleJSON("The Breakfast Show")
+	session, _ := scriptedSession(map[string][]string{
+		"/timeslot/weekschedule/46": {before, before, after},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	diffs := make(chan ScheduleDiff)
+	done := make(chan error)
+	go func() {
+		done <- session.NewScheduleWatcher(2016, 46, time.Millisecond).Run(ctx, diffs)
+	}()
+
+	diff := <-diffs
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Retimed) != 0 || len(diff.Retitled) != 1 {
+		t.Fatal("expected one retitled timeslot, got:", diff)
+	}
+	if from, to := diff.Retitled[0].Old.Title, diff.Retitled[0].New.Title; from != "Breakfast" || to != "The Breakfast Show" {
+		t.Errorf("expected retitling from Breakfast to The Breakfast Show, got %q to %q", from, to)
+	}
+
+	// The schedule doesn't change again, so there should be no further diffs.
+	select {
+	case diff := <-diffs:
+		t.Error("unexpected diff:", diff)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Error("expected:", context.Canceled, "got:", err)
+	}
+}
+
+// TestSelectorWatcher tests whether a SelectorWatcher reports only changes to the selector's studio, lock and
+// power, and stops cleanly.
+// It does not test the API endpoint.
+func TestSelectorWatcher(t *testing.T) {
+	state := func(studio Studio, lock SelectorLock, power StudioPower) string {
+		return fmt.Sprintf(`{"studio": %d, "lock": %d, "selectedfrom": 1, "power": %d}`, studio, lock, power)
+	}
+	jukebox := state(StudioJukebox, LockOff, OnBoth)
+	locked := state(StudioJukebox, LockKey, OnBoth)
+	live := state(Studio1, LockKey, OnBoth)
+	powerDown := state(Studio1, LockKey, OnS1)
+	session, _ := scriptedSession(map[string][]string{
+		"/selector/query": {jukebox, jukebox, locked, locked, live, powerDown},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan SelectorEvent)
+	done := make(chan error)
+	go func() {
+		done <- session.NewSelectorWatcher(time.Millisecond).Run(ctx, events)
+	}()
+
+	expected := []struct {
+		now           SelectorInfo
+		sourceChanged bool
+	}{
+		{SelectorInfo{Studio: StudioJukebox, Lock: LockOff, SelectedFrom: 1, Power: OnBoth}, true},
+		{SelectorInfo{Studio: StudioJukebox, Lock: LockKey, SelectedFrom: 1, Power: OnBoth}, false},
+		{SelectorInfo{Studio: Studio1, Lock: LockKey, SelectedFrom: 1, Power: OnBoth}, true},
+		{SelectorInfo{Studio: Studio1, Lock: LockKey, SelectedFrom: 1, Power: OnS1}, false},
+	}
+	var last *SelectorInfo
+	for i, e := range expected {
+		ev := <-events
+		if ev.Now != e.now || ev.SourceChanged != e.sourceChanged {
+			t.Errorf("event %d: expected %v with source change %v, got %v with source change %v",
+				i, e.now, e.sourceChanged, ev.Now, ev.SourceChanged)
+		}
+		if (ev.Previous == nil) != (last == nil) || ev.Previous != nil && *ev.Previous != *last {
+			t.Errorf("event %d: expected previous state %v, got %v", i, last, ev.Previous)
+		}
+		now := ev.Now
+		last = &now
+	}
+
+	// The last state repeats forever, so there should be no further events.
+	select {
+	case ev := <-events:
+		t.Error("unexpected event:", ev)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Error("expected:", context.Canceled, "got:", err)
+	}
+}